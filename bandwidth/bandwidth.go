@@ -0,0 +1,307 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package bandwidth
+
+import (
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/shingetsu-gou/shingetsu-gou/cfg"
+	"github.com/shingetsu-gou/shingetsu-gou/db"
+)
+
+//directions of traffic.
+const (
+	Upload = iota
+	Download
+)
+
+//states of traffic.
+const (
+	Normal    = iota
+	Throttled //over the soft cap, traffic is limited by ThrottleRate.
+	Capped    //over the hard cap, heavy requests are refused.
+)
+
+//saveInterval is the interval for saving totals to db.
+const saveInterval = time.Minute
+
+//ErrCapped is returned if a heavy request is refused because of the bandwidth cap.
+var ErrCapped = errors.New("bandwidth cap is exceeded")
+
+var names = [...]string{"upload", "download"}
+
+//meter counts and limits traffic in a direction.
+type meter struct {
+	sync.Mutex
+	dir     int
+	day     string
+	month   string
+	daily   int64
+	monthly int64
+	unsaved int64
+	saved   time.Time
+	tokens  float64
+	last    time.Time
+}
+
+var meters = [...]*meter{{dir: Upload}, {dir: Download}}
+
+//key returns the db key of the total of dir in the period.
+func key(dir int, period string) []byte {
+	return []byte(names[dir] + ":" + period)
+}
+
+//load returns the total of dir in the period from db.
+func load(dir int, period string) int64 {
+	var v int64
+	err := db.DB.View(func(tx *bolt.Tx) error {
+		_, err := db.Get(tx, "bandwidth", key(dir, period), &v)
+		return err
+	})
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
+//save adds n bytes to the totals of dir in the day and the month in db.
+func save(dir int, day, month string, n int64) {
+	if n == 0 {
+		return
+	}
+	err := db.DB.Batch(func(tx *bolt.Tx) error {
+		for _, p := range []string{day, month} {
+			var v int64
+			if _, err := db.Get(tx, "bandwidth", key(dir, p), &v); err != nil {
+				v = 0
+			}
+			if err := db.Put(tx, "bandwidth", key(dir, p), v+n); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+//roll saves unsaved traffic and loads totals from db if the day is changed.
+func (m *meter) roll(now time.Time) {
+	day := now.Format("2006-01-02")
+	if day == m.day {
+		return
+	}
+	save(m.dir, m.day, m.month, m.unsaved)
+	m.day = day
+	m.month = now.Format("2006-01")
+	m.daily = load(m.dir, m.day)
+	m.monthly = load(m.dir, m.month)
+	m.unsaved = 0
+	m.saved = now
+}
+
+//caps returns the daily and monthly caps in bytes, 0 means unlimited.
+func (m *meter) caps() (int64, int64) {
+	if m.dir == Upload {
+		return cfg.DailyUploadCap * 1024 * 1024, cfg.MonthlyUploadCap * 1024 * 1024
+	}
+	return cfg.DailyDownloadCap * 1024 * 1024, cfg.MonthlyDownloadCap * 1024 * 1024
+}
+
+//state returns the state of m by the caps.
+func (m *meter) state() int {
+	daily, monthly := m.caps()
+	st := Normal
+	for _, t := range []struct{ total, cap int64 }{{m.daily, daily}, {m.monthly, monthly}} {
+		switch {
+		case t.cap <= 0:
+		case t.total >= t.cap:
+			return Capped
+		case t.total*100 >= t.cap*int64(cfg.SoftCap):
+			st = Throttled
+		}
+	}
+	return st
+}
+
+//rate returns bytes per second allowed in the state st, or 0 if unlimited.
+func (m *meter) rate(st int) float64 {
+	r := cfg.UploadRate
+	if m.dir == Download {
+		r = cfg.DownloadRate
+	}
+	if st != Normal && cfg.ThrottleRate > 0 && (r <= 0 || cfg.ThrottleRate < r) {
+		r = cfg.ThrottleRate
+	}
+	return float64(r)
+}
+
+//add adds n bytes to the totals and returns the time to wait for keeping the rate.
+func (m *meter) add(n int64, now time.Time) time.Duration {
+	m.Lock()
+	m.roll(now)
+	m.daily += n
+	m.monthly += n
+	m.unsaved += n
+	var wait time.Duration
+	if r := m.rate(m.state()); r > 0 {
+		if m.last.IsZero() {
+			m.tokens = r
+		} else if d := now.Sub(m.last).Seconds(); d > 0 {
+			m.tokens += d * r
+			if m.tokens > r {
+				m.tokens = r
+			}
+		}
+		m.last = now
+		m.tokens -= float64(n)
+		if m.tokens < 0 {
+			wait = time.Duration(-m.tokens / r * float64(time.Second))
+		}
+	}
+	if now.Sub(m.saved) < saveInterval {
+		m.Unlock()
+		return wait
+	}
+	day, month, unsaved := m.day, m.month, m.unsaved
+	m.unsaved = 0
+	m.saved = now
+	m.Unlock()
+	save(m.dir, day, month, unsaved)
+	return wait
+}
+
+//Count counts n bytes transferred in the direction dir,
+//and sleeps if the traffic is over the rate.
+func Count(dir int, n int) {
+	if n <= 0 {
+		return
+	}
+	if wait := meters[dir].add(int64(n), time.Now()); wait > 0 {
+		time.Sleep(wait)
+	}
+}
+
+//State returns the state of traffic in the direction dir.
+func State(dir int) int {
+	m := meters[dir]
+	m.Lock()
+	defer m.Unlock()
+	m.roll(time.Now())
+	return m.state()
+}
+
+//Totals returns bytes transferred in the direction dir today and this month.
+func Totals(dir int) (int64, int64) {
+	m := meters[dir]
+	m.Lock()
+	defer m.Unlock()
+	m.roll(time.Now())
+	return m.daily, m.monthly
+}
+
+//Reset returns the duration until the cap in the direction dir is reset,
+//which is the next day if over the daily cap, or the next month.
+func Reset(dir int) time.Duration {
+	m := meters[dir]
+	m.Lock()
+	defer m.Unlock()
+	now := time.Now()
+	m.roll(now)
+	y, mon, d := now.Date()
+	if daily, _ := m.caps(); daily > 0 && m.daily >= daily {
+		return time.Date(y, mon, d+1, 0, 0, 0, 0, now.Location()).Sub(now)
+	}
+	return time.Date(y, mon+1, 1, 0, 0, 0, 0, now.Location()).Sub(now)
+}
+
+//Heavy returns true if the method of server.cgi transfers many records,
+//which is refused over the hard cap.
+func Heavy(method string) bool {
+	switch method {
+	case "get", "head", "removed", "summary", "bulk", "recent":
+		return true
+	}
+	return false
+}
+
+//conn is net.Conn which counts traffic.
+type conn struct {
+	net.Conn
+}
+
+//NewConn returns net.Conn which counts traffic of c.
+func NewConn(c net.Conn) net.Conn {
+	return &conn{c}
+}
+
+//Read reads from the connection and counts as download.
+func (c *conn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+	Count(Download, n)
+	return n, err
+}
+
+//Write writes to the connection and counts as upload.
+func (c *conn) Write(p []byte) (int, error) {
+	n, err := c.Conn.Write(p)
+	Count(Upload, n)
+	return n, err
+}
+
+//responseWriter is http.ResponseWriter which counts traffic.
+type responseWriter struct {
+	http.ResponseWriter
+}
+
+//NewResponseWriter returns http.ResponseWriter which counts traffic of w.
+func NewResponseWriter(w http.ResponseWriter) http.ResponseWriter {
+	return &responseWriter{w}
+}
+
+//Write writes the response and counts as upload.
+func (w *responseWriter) Write(p []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(p)
+	Count(Upload, n)
+	return n, err
+}
+
+//Flush flushes the response if possible.
+func (w *responseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package bandwidth
+
+import (
+	"testing"
+
+	"github.com/shingetsu-gou/shingetsu-gou/cfg"
+)
+
+func TestState(t *testing.T) {
+	cfg.DailyUploadCap = 10
+	cfg.MonthlyUploadCap = 100
+	cfg.SoftCap = 80
+	cfg.UploadRate = 0
+	cfg.ThrottleRate = 1024
+	m := &meter{dir: Upload}
+	for _, c := range []struct {
+		daily, monthly int64
+		state          int
+		rate           float64
+	}{
+		{1 << 20, 1 << 20, Normal, 0},
+		{8 << 20, 8 << 20, Throttled, 1024},
+		{1 << 20, 90 << 20, Throttled, 1024},
+		{10 << 20, 10 << 20, Capped, 1024},
+		{1 << 20, 100 << 20, Capped, 1024},
+	} {
+		m.daily, m.monthly = c.daily, c.monthly
+		if st := m.state(); st != c.state || m.rate(st) != c.rate {
+			t.Error("illegal state", c, st, m.rate(st))
+		}
+	}
+	cfg.DailyUploadCap = 0
+	cfg.MonthlyUploadCap = 0
+	m.daily, m.monthly = 1<<40, 1<<40
+	if m.state() != Normal {
+		t.Error("capped without caps")
+	}
+}
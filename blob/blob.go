@@ -0,0 +1,131 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package blob
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+
+	"github.com/boltdb/bolt"
+	"github.com/shingetsu-gou/shingetsu-gou/db"
+)
+
+//Hash returns the key of data in the blob store, which is hex string of sha256.
+func Hash(data []byte) string {
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
+
+//PutTX saves data to the blob store if not saved, increments # of references
+//to it, and returns its hash.
+func PutTX(tx *bolt.Tx, data []byte) (string, error) {
+	hash := Hash(data)
+	var refs int64
+	if _, err := db.Get(tx, "blobRef", []byte(hash), &refs); err != nil {
+		refs = 0
+	}
+	if refs == 0 {
+		if err := db.Put(tx, "blob", []byte(hash), data); err != nil {
+			return "", err
+		}
+	}
+	return hash, db.Put(tx, "blobRef", []byte(hash), refs+1)
+}
+
+//GetTX returns data whose hash is hash in the blob store.
+//returned data is valid only while tx is open.
+func GetTX(tx *bolt.Tx, hash string) ([]byte, error) {
+	b := tx.Bucket([]byte("blob"))
+	if b == nil {
+		return nil, errors.New("bucket not found blob")
+	}
+	data := b.Get([]byte(hash))
+	if data == nil {
+		return nil, errors.New("blob not found " + hash)
+	}
+	return data, nil
+}
+
+//Get returns a copy of data whose hash is hash in the blob store.
+func Get(hash string) ([]byte, error) {
+	var data []byte
+	err := db.DB.View(func(tx *bolt.Tx) error {
+		d, err := GetTX(tx, hash)
+		if err != nil {
+			return err
+		}
+		data = make([]byte, len(d))
+		copy(data, d)
+		return nil
+	})
+	if err != nil {
+		log.Println(err)
+	}
+	return data, err
+}
+
+//LenTX returns the size of data whose hash is hash, or 0 if not found.
+func LenTX(tx *bolt.Tx, hash string) int {
+	data, err := GetTX(tx, hash)
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
+
+//Len returns the size of data whose hash is hash, or 0 if not found.
+func Len(hash string) int {
+	var l int
+	err := db.DB.View(func(tx *bolt.Tx) error {
+		l = LenTX(tx, hash)
+		return nil
+	})
+	if err != nil {
+		log.Println(err)
+	}
+	return l
+}
+
+//ReleaseTX decrements # of references to data whose hash is hash,
+//and removes the data if it is not referred any more.
+func ReleaseTX(tx *bolt.Tx, hash string) error {
+	var refs int64
+	if _, err := db.Get(tx, "blobRef", []byte(hash), &refs); err != nil {
+		return err
+	}
+	if refs > 1 {
+		return db.Put(tx, "blobRef", []byte(hash), refs-1)
+	}
+	if err := db.Del(tx, "blobRef", []byte(hash)); err != nil {
+		return err
+	}
+	return db.Del(tx, "blob", []byte(hash))
+}
@@ -50,6 +50,8 @@ const (
 	UPnP
 	//Normal represents port was opened manually.
 	Normal
+	//Relay represents mynode is behind NAT and relayed by another node.
+	Relay
 )
 
 const (
@@ -61,12 +63,15 @@ const (
 	ThreadURL = "/thread.cgi"
 	//ServerURL is the url to server.cgi
 	ServerURL = "/server.cgi"
+	//APIURL is the url to JSON API
+	APIURL = "/api/v1"
 )
 
 //data Errors.
 var (
-	ErrSpam = errors.New("this is spam")
-	ErrGet  = errors.New("cannot get data")
+	ErrSpam   = errors.New("this is spam")
+	ErrGet    = errors.New("cannot get data")
+	ErrForged = errors.New("signature is invalid")
 )
 
 var (
@@ -75,6 +80,10 @@ var (
 	}
 	//InitNode is initiali nodes.
 	InitNode *util.ConfList
+	//Moderators is pubkeys which can remove records of others.
+	Moderators *util.ConfList
+	//Pinned is threads which are not evicted by the storage quota.
+	Pinned *util.ConfList
 )
 
 //cwd represents current working dir.
@@ -99,17 +108,46 @@ var (
 	TemplateDir string
 
 	NetworkMode          int //port_opened,relay,upnp
+	RelayServer          string
+	MaxRelayClients      int
+	MaxIdleConns         int
+	MaxConnsPerHost      int
+	IdleConnTimeout      int64
+	DialTimeout          int64
+	ResponseTimeout      int64
+	ReadTimeout          int64
+	Proxy                string
+	ProxyHosts           string
 	SaveRecord           int64
 	SaveSize             int // It is not seconds, but number.
 	GetRange             int64
 	SyncRange            int64
 	SaveRemoved          int64
-	DefaultPort          int //DefaultPort is listening port
+	StorageQuota         int64 // MB.
+	DefaultPort          int   //DefaultPort is listening port
 	MaxConnection        int
+	RateLimit            int // requests per minute per remote IP and method.
+	RateBurst            int
+	JoinRateLimit        int
+	BanTime              int64
+	BanViolations        int
+	MaxResponseSize      int64
+	UploadRate           int64 // bytes per second.
+	DownloadRate         int64
+	DailyUploadCap       int64 // MB.
+	MonthlyUploadCap     int64
+	DailyDownloadCap     int64
+	MonthlyDownloadCap   int64
+	SoftCap              int // percentage of caps where traffic is throttled.
+	ThrottleRate         int64
+	LANDiscovery         bool
+	LANGroup             string
 	SpamList             string
 	InitnodeList         string
 	NodeAllowFile        string
 	NodeDenyFile         string
+	ModeratorList        string
+	PinnedList           string
 	ReAdminStr           string
 	ReFriendStr          string
 	ReVisitorStr         string
@@ -120,6 +158,7 @@ var (
 	RecentRange          int64
 	RecordLimit          int
 	ThreadPageSize       int
+	SearchPageSize       int
 	DefaultThumbnailSize string
 	Enable2ch            bool
 	ForceThumbnail       bool
@@ -201,6 +240,8 @@ func Parse() {
 	}
 	initVariables(i)
 	InitNode = util.NewConfList(InitnodeList, defaultInitNode)
+	Moderators = util.NewConfList(ModeratorList, nil)
+	Pinned = util.NewConfList(PinnedList, nil)
 }
 
 func networkMode(i *ini.File) {
@@ -210,6 +251,8 @@ func networkMode(i *ini.File) {
 		NetworkMode = Normal
 	case "upnp":
 		NetworkMode = UPnP
+	case "relay":
+		NetworkMode = Relay
 	default:
 		log.Println("cannot understand mode", networkModeStr)
 		NetworkMode = Normal
@@ -230,6 +273,8 @@ func initVariables(i *ini.File) {
 		InitnodeList = getRelativePathValue(i, "Path", "initnode_list", "../file/initnode.txt", Docroot)
 		NodeAllowFile = getRelativePathValue(i, "Path", "node_allow", "../file/node_allow.txt", Docroot)
 		NodeDenyFile = getRelativePathValue(i, "Path", "node_deny", "../file/node_deny.txt", Docroot)
+		ModeratorList = getRelativePathValue(i, "Path", "moderator_list", "../file/moderator.txt", Docroot)
+		PinnedList = getRelativePathValue(i, "Path", "pinned_list", "../file/pinned.txt", Docroot)
 	} else {
 		Docroot = filepath.Join(cwd, "www")
 		RunDir = filepath.Join(cwd, "run")
@@ -240,8 +285,36 @@ func initVariables(i *ini.File) {
 		InitnodeList = filepath.Join(cwd, "file", "initnode.txt")
 		NodeAllowFile = filepath.Join(cwd, "file", "node_allow.txt")
 		NodeDenyFile = filepath.Join(cwd, "file", "node_deny.txt")
+		ModeratorList = filepath.Join(cwd, "file", "moderator.txt")
+		PinnedList = filepath.Join(cwd, "file", "pinned.txt")
 	}
 	MaxConnection = getIntValue(i, "Network", "max_connection", 100)
+	RateLimit = getIntValue(i, "Network", "rate_limit", 120)
+	RateBurst = getIntValue(i, "Network", "rate_burst", 60)
+	JoinRateLimit = getIntValue(i, "Network", "join_rate_limit", 2)
+	BanTime = getInt64Value(i, "Network", "ban_time", 60*60)
+	BanViolations = getIntValue(i, "Network", "ban_violations", 10)
+	MaxResponseSize = getInt64Value(i, "Network", "max_response_size", 64*1024*1024)
+	UploadRate = getInt64Value(i, "Network", "upload_rate", 0)
+	DownloadRate = getInt64Value(i, "Network", "download_rate", 0)
+	DailyUploadCap = getInt64Value(i, "Network", "daily_upload_cap", 0)
+	MonthlyUploadCap = getInt64Value(i, "Network", "monthly_upload_cap", 0)
+	DailyDownloadCap = getInt64Value(i, "Network", "daily_download_cap", 0)
+	MonthlyDownloadCap = getInt64Value(i, "Network", "monthly_download_cap", 0)
+	SoftCap = getIntValue(i, "Network", "soft_cap", 80)
+	ThrottleRate = getInt64Value(i, "Network", "throttle_rate", 16*1024)
+	LANDiscovery = getBoolValue(i, "Network", "lan_discovery", false)
+	LANGroup = getStringValue(i, "Network", "lan_group", "239.255.77.77:8077")
+	RelayServer = getStringValue(i, "Network", "relay_server", "")
+	MaxRelayClients = getIntValue(i, "Network", "max_relay_clients", 10)
+	MaxIdleConns = getIntValue(i, "Network", "max_idle_conns", 100)
+	MaxConnsPerHost = getIntValue(i, "Network", "max_conns_per_host", 4)
+	IdleConnTimeout = getInt64Value(i, "Network", "idle_conn_timeout", 90)
+	DialTimeout = getInt64Value(i, "Network", "dial_timeout", 15)
+	ResponseTimeout = getInt64Value(i, "Network", "response_timeout", 15)
+	ReadTimeout = getInt64Value(i, "Network", "read_timeout", 60)
+	Proxy = getStringValue(i, "Network", "proxy", "")
+	ProxyHosts = getStringValue(i, "Network", "proxy_hosts", "")
 	ReAdminStr = getStringValue(i, "Gateway", "admin", "^(127|\\[::1\\])")
 	ReFriendStr = getStringValue(i, "Gateway", "friend", "^(127|\\[::1\\])")
 	ReVisitorStr = getStringValue(i, "Gateway", "visitor", ".")
@@ -255,6 +328,7 @@ func initVariables(i *ini.File) {
 	EnableProf = getBoolValue(i, "Gateway", "enable_prof", false)
 	HeavyMoon = getBoolValue(i, "Gateway", "moonlight", false)
 	EnableEmbed = getBoolValue(i, "Gateway", "enable_embed", true)
+	SearchPageSize = getIntValue(i, "Gateway", "search_page_size", 20)
 	ThreadPageSize = getIntValue(i, "Application Thread", "page_size", 50)
 	DefaultThumbnailSize = getStringValue(i, "Application Thread", "thumbnail_size", "")
 	ForceThumbnail = getBoolValue(i, "Application Thread", "force_thumbnail", false)
@@ -273,6 +347,7 @@ func initVariables(i *ini.File) {
 	if SaveRemoved > time.Now().Unix() {
 		log.Fatal("save_removed is too big")
 	}
+	StorageQuota = getInt64Value(i, ctype, "storage_quota", 0)
 
 	if SyncRange == 0 {
 		SaveRecord = 0
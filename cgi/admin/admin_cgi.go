@@ -34,25 +34,29 @@ import (
 	"log"
 	"math/rand"
 	"net/http"
-	"regexp"
 	"runtime"
-	"sort"
 	"strconv"
 	"strings"
 	"time"
 	"errors"
 
+	"github.com/boltdb/bolt"
+	"github.com/shingetsu-gou/shingetsu-gou/bandwidth"
 	"github.com/shingetsu-gou/shingetsu-gou/cfg"
 	"github.com/shingetsu-gou/shingetsu-gou/cgi"
+	"github.com/shingetsu-gou/shingetsu-gou/db"
+	"github.com/shingetsu-gou/shingetsu-gou/fsck"
 	"github.com/shingetsu-gou/shingetsu-gou/myself"
 	"github.com/shingetsu-gou/shingetsu-gou/node"
 	"github.com/shingetsu-gou/shingetsu-gou/node/manager"
 	"github.com/shingetsu-gou/shingetsu-gou/recentlist"
 	"github.com/shingetsu-gou/shingetsu-gou/record"
+	"github.com/shingetsu-gou/shingetsu-gou/search"
 	"github.com/shingetsu-gou/shingetsu-gou/tag"
 	"github.com/shingetsu-gou/shingetsu-gou/tag/suggest"
 	"github.com/shingetsu-gou/shingetsu-gou/tag/user"
 	"github.com/shingetsu-gou/shingetsu-gou/thread"
+	"github.com/shingetsu-gou/shingetsu-gou/updateque"
 	"github.com/shingetsu-gou/shingetsu-gou/util"
 )
 
@@ -64,6 +68,10 @@ func Setup(s *cgi.LoggingServeMux) {
 	s.RegistCompressHandler(cfg.AdminURL+"/edittag", printEdittag)
 	s.RegistCompressHandler(cfg.AdminURL+"/savetag", saveTagCGI)
 	s.RegistCompressHandler(cfg.AdminURL+"/search", printSearch)
+	s.RegistCompressHandler(cfg.AdminURL+"/updates", printUpdates)
+	s.RegistCompressHandler(cfg.AdminURL+"/nodes", printNodeHealth)
+	s.RegistCompressHandler(cfg.AdminURL+"/retention", printRetention)
+	s.RegistCompressHandler(cfg.AdminURL+"/fsck", printFsck)
 	s.RegistCompressHandler(cfg.AdminURL+"/", execCmd)
 }
 
@@ -104,13 +112,154 @@ func printSearch(w http.ResponseWriter, r *http.Request) {
 	if query == "" {
 		a.Header(a.M["search"], "", nil, true)
 		fmt.Fprintf(a.WR, "<p>%s</p>", a.M["desc_search"])
-		a.printSearchForm("")
+		a.PrintSearchForm(cfg.AdminURL+"/search", "")
 		a.Footer(nil)
 	} else {
 		a.printSearchResult(query)
 	}
 }
 
+//printUpdates renders pending, failed and delivered updates in the update queue.
+//if cmd=retry, makes failed updates pending again.
+func printUpdates(w http.ResponseWriter, r *http.Request) {
+	a, err := new(w, r)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if a.Req.FormValue("cmd") == "retry" {
+		if a.Req.Method != "POST" || !a.checkSid() {
+			a.Print404(nil, "")
+			return
+		}
+		updateque.RetryFailed()
+		a.Print302(cfg.AdminURL + "/updates")
+		return
+	}
+	type queue struct {
+		Name    string
+		Updates []*updateque.Update
+	}
+	d := struct {
+		Queues []*queue
+		Sid    string
+		cgi.Defaults
+	}{
+		[]*queue{
+			{"pending", updateque.List(updateque.Pending)},
+			{"failed", updateque.List(updateque.Failed)},
+			{"delivered", updateque.List(updateque.Delivered)},
+		},
+		a.makeSid(),
+		*a.Defaults(),
+	}
+	a.Header(a.M["update_queue"], "", nil, true)
+	cgi.RenderTemplate("update_queue", d, a.WR)
+	a.Footer(nil)
+}
+
+//printNodeHealth renders health scores of nodes which have talked with.
+func printNodeHealth(w http.ResponseWriter, r *http.Request) {
+	a, err := new(w, r)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	d := struct {
+		Healths node.Healths
+		cgi.Defaults
+	}{
+		node.AllHealths(),
+		*a.Defaults(),
+	}
+	a.Header(a.M["node_health"], "", nil, true)
+	cgi.RenderTemplate("node_health", d, a.WR)
+	a.Footer(nil)
+}
+
+//printRetention renders threads which have records to be removed by retention settings.
+//if cmd=run, removes them now.
+func printRetention(w http.ResponseWriter, r *http.Request) {
+	a, err := new(w, r)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if a.Req.FormValue("cmd") == "run" {
+		if a.Req.Method != "POST" || !a.checkSid() {
+			a.Print404(nil, "")
+			return
+		}
+		thread.CleanRecords()
+		thread.RemoveRemoved()
+		a.Print302(cfg.AdminURL + "/retention")
+		return
+	}
+	d := struct {
+		Retentions  []*thread.Retention
+		SaveRecord  int64
+		SaveSize    int
+		SaveRemoved int64
+		SyncRange   int64
+		Sid         string
+		cgi.Defaults
+	}{
+		thread.Retentions(),
+		cfg.SaveRecord / (24 * 60 * 60),
+		cfg.SaveSize,
+		cfg.SaveRemoved / (24 * 60 * 60),
+		cfg.SyncRange / (24 * 60 * 60),
+		a.makeSid(),
+		*a.Defaults(),
+	}
+	a.Header(a.M["retention"], "", nil, true)
+	cgi.RenderTemplate("retention", d, a.WR)
+	a.Footer(nil)
+}
+
+//printFsck renders problems in db.
+//if cmd=repair, repairs them and renders the result.
+func printFsck(w http.ResponseWriter, r *http.Request) {
+	a, err := new(w, r)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	repair := a.Req.FormValue("cmd") == "repair"
+	if repair && (a.Req.Method != "POST" || !a.checkSid()) {
+		a.Print404(nil, "")
+		return
+	}
+	ps, err := fsck.Check(repair)
+	var errstr string
+	if err != nil {
+		log.Println(err)
+		errstr = err.Error()
+	}
+	repairable := false
+	for _, p := range ps {
+		repairable = repairable || p.Repairable()
+	}
+	d := struct {
+		Problems   fsck.Problems
+		Repaired   bool
+		Repairable bool
+		Err        string
+		Sid        string
+		cgi.Defaults
+	}{
+		ps,
+		repair && err == nil,
+		repairable,
+		errstr,
+		a.makeSid(),
+		*a.Defaults(),
+	}
+	a.Header(a.M["fsck"], "", nil, true)
+	cgi.RenderTemplate("fsck", d, a.WR)
+	a.Footer(nil)
+}
+
 //printStatus renders status info, including
 //#linknodes,#knownNodes,#files,#records,cacheSize,selfnode/linknodes/knownnodes
 // ip:port,
@@ -140,6 +289,8 @@ func printStatus(w http.ResponseWriter, r *http.Request) {
 		port0 = a.M["port0"]
 	case cfg.Disconnected:
 		port0 = a.M["disconnected"]
+	case cfg.Relay:
+		port0 = a.M["relayed"] + " " + myself.GetRelayNode()
 	}
 
 	s := map[string]string{
@@ -147,10 +298,12 @@ func printStatus(w http.ResponseWriter, r *http.Request) {
 		"linked_nodes":      strconv.Itoa(manager.ListLen()),
 		"files":             strconv.Itoa(thread.Len()),
 		"records":           strconv.Itoa(records),
-		"cache_size":        fmt.Sprintf("%.1f%s", float64(size)/1024/1024, a.M["mb"]),
+		"cache_size":        cacheSize(a, size),
 		"self_node":         node.Me(false).Nodestr,
 		"alloc_mem":         fmt.Sprintf("%.1f%s", float64(mem.Alloc)/1024/1024, a.M["mb"]),
 		"connection_status": port0,
+		"uploaded":          traffic(a, bandwidth.Upload),
+		"downloaded":        traffic(a, bandwidth.Download),
 	}
 	ns := map[string][]string{
 		"known_nodes":  manager.GetNodestrSlice(),
@@ -161,16 +314,33 @@ func printStatus(w http.ResponseWriter, r *http.Request) {
 		Status     map[string]string
 		NodeStatus map[string][]string
 		Message    cgi.Message
+		AdminCGI   string
 	}{
 		s,
 		ns,
 		a.M,
+		cfg.AdminURL,
 	}
 	a.Header(a.M["status"], "", nil, true)
 	cgi.RenderTemplate("status", d, a.WR)
 	a.Footer(nil)
 }
 
+//cacheSize returns size of records in MB, with storage_quota if set.
+func cacheSize(a *adminCGI, size int64) string {
+	s := fmt.Sprintf("%.1f%s", float64(size)/1024/1024, a.M["mb"])
+	if cfg.StorageQuota > 0 {
+		s += fmt.Sprintf(" (%s %d%s)", a.M["limit"], cfg.StorageQuota, a.M["mb"])
+	}
+	return s
+}
+
+//traffic returns bytes transferred in the direction dir today and this month in MB.
+func traffic(a *adminCGI, dir int) string {
+	daily, monthly := bandwidth.Totals(dir)
+	return fmt.Sprintf("%.1f%s / %.1f%s", float64(daily)/1024/1024, a.M["mb"], float64(monthly)/1024/1024, a.M["mb"])
+}
+
 //printEdittag renders the page for editing tags in thread specified by form "file".
 func printEdittag(w http.ResponseWriter, r *http.Request) {
 	a, err := new(w, r)
@@ -356,9 +526,17 @@ func (a *adminCGI) postDeleteMessage(ca *thread.Cache, rec *record.Record) {
 	body["remove_id"] = rec.ID
 	passwd := a.Req.FormValue("passwd")
 	id := rec.Build(stamp, body, passwd)
-	rec.Sync()
+	err := db.DB.Update(func(tx *bolt.Tx) error {
+		if err := rec.SyncTX(tx, false); err != nil {
+			return err
+		}
+		return rec.RemoveTargetTX(tx)
+	})
+	if err != nil {
+		log.Println(err)
+	}
 	recentlist.Append(rec.Head)
-	go manager.TellUpdate(ca.Datfile, stamp, id, nil)
+	go updateque.TellUpdate(ca.Datfile, stamp, id, nil)
 }
 
 //printDeleteFile renders the page for confirmation of deleting file.
@@ -403,42 +581,22 @@ func (a *adminCGI) doDeleteFile(files []string) {
 	a.Print302(cfg.GatewayURL + "/" + "changes")
 }
 
-//printSearchForm renders search_form.txt
-func (a *adminCGI) printSearchForm(query string) {
-	d := struct {
-		Query    string
-		AdminCGI string
-		Message  cgi.Message
-	}{
-		query,
-		cfg.AdminURL,
-		a.M,
-	}
-	cgi.RenderTemplate("search_form", d, a.WR)
-}
-
-//printSearchResult renders cachelist that its datfile matches query.
+//printSearchResult renders cachelist that its records or title match query,
+//sorted by scores.
 func (a *adminCGI) printSearchResult(query string) {
 	strQuery := html.EscapeString(query)
 	title := fmt.Sprintf("%s: %s", a.M["search"], strQuery)
 	a.Header(title, "", nil, true)
 	fmt.Fprintf(a.WR, "<p>%s</p>", a.M["desc_search"])
-	a.printSearchForm(strQuery)
-	reg := html.EscapeString(query)
-	regg, err := regexp.Compile(reg)
-	if err != nil {
-		log.Println(err)
-		return
-	}
-	result := thread.Search(reg)
+	a.PrintSearchForm(cfg.AdminURL+"/search", query)
+	result := thread.Search(query)
 	for _, i := range thread.AllCaches() {
 		if result.Has(i) {
 			continue
 		}
-		if regg.MatchString(util.FileDecode(i.Datfile)) {
+		if search.Match(util.FileDecode(i.Datfile), query) {
 			result = append(result, i)
 		}
 	}
-	sort.Sort(sort.Reverse(thread.NewSortByStamp(result, false)))
 	a.PrintIndexList(result, "", true, false, "", "")
 }
@@ -0,0 +1,442 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package api
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/handlers"
+	"github.com/gorilla/mux"
+	"github.com/shingetsu-gou/shingetsu-gou/cfg"
+	"github.com/shingetsu-gou/shingetsu-gou/cgi"
+	threadCGI "github.com/shingetsu-gou/shingetsu-gou/cgi/thread"
+	"github.com/shingetsu-gou/shingetsu-gou/record"
+	"github.com/shingetsu-gou/shingetsu-gou/tag/suggest"
+	"github.com/shingetsu-gou/shingetsu-gou/tag/user"
+	"github.com/shingetsu-gou/shingetsu-gou/thread"
+	"github.com/shingetsu-gou/shingetsu-gou/util"
+)
+
+//Setup setups handlers for the JSON API.
+func Setup(s *cgi.LoggingServeMux) {
+	rtr := mux.NewRouter()
+	datfile := cfg.APIURL + "/threads/{datfile:thread_[0-9A-F]+}"
+
+	rtr.HandleFunc(cfg.APIURL+"/threads", printThreads).Methods("GET", "HEAD")
+	rtr.HandleFunc(datfile, printThread).Methods("GET", "HEAD")
+	rtr.HandleFunc(datfile+"/records", printRecords).Methods("GET", "HEAD")
+	rtr.HandleFunc(datfile+"/records", postRecord).Methods("POST")
+	rtr.HandleFunc(cfg.APIURL+"/recent", printRecent).Methods("GET", "HEAD")
+	rtr.HandleFunc(cfg.APIURL+"/tags", printTags).Methods("GET", "HEAD")
+	rtr.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		renderError(w, http.StatusNotFound, "not found")
+	})
+
+	s.Handle(cfg.APIURL+"/", handlers.CompressHandler(rtr))
+}
+
+//threadInfo is a thread in JSON.
+type threadInfo struct {
+	Datfile       string   `json:"datfile"`
+	Title         string   `json:"title"`
+	Stamp         int64    `json:"stamp"`
+	RecentStamp   int64    `json:"recent_stamp,omitempty"`
+	Records       int      `json:"records"`
+	Size          int64    `json:"size"`
+	Velocity      int      `json:"velocity"`
+	Tags          []string `json:"tags"`
+	SuggestedTags []string `json:"suggested_tags,omitempty"`
+}
+
+//newThreadInfo returns threadInfo of ca.
+//if recent is true, also sets recent stamp and suggested tags.
+func newThreadInfo(ca *thread.Cache, recent bool) *threadInfo {
+	t := &threadInfo{
+		Datfile:  ca.Datfile,
+		Title:    util.FileDecode(ca.Datfile),
+		Stamp:    ca.Stamp(),
+		Records:  ca.Len(record.Alive),
+		Size:     ca.Size(),
+		Velocity: ca.Velocity(),
+		Tags:     user.GetStrings(ca.Datfile),
+	}
+	if t.Tags == nil {
+		t.Tags = []string{}
+	}
+	if recent {
+		t.RecentStamp = ca.RecentStamp()
+		t.SuggestedTags = suggest.Get(ca.Datfile, nil).GetTagstrSlice()
+	}
+	return t
+}
+
+//attachInfo is an attached file of a record in JSON.
+type attachInfo struct {
+	Suffix string `json:"suffix"`
+	Size   int    `json:"size"`
+	URL    string `json:"url"`
+}
+
+//recordInfo is a record in JSON, whose body is parsed.
+type recordInfo struct {
+	ID          string      `json:"id"`
+	Stamp       int64       `json:"stamp"`
+	Name        string      `json:"name,omitempty"`
+	Mail        string      `json:"mail,omitempty"`
+	Body        string      `json:"body,omitempty"`
+	Pubkey      string      `json:"pubkey,omitempty"`
+	SignOK      bool        `json:"sign_ok"`
+	Attach      *attachInfo `json:"attach,omitempty"`
+	BaseID      string      `json:"base_id,omitempty"`
+	RemoveStamp string      `json:"remove_stamp,omitempty"`
+	RemoveID    string      `json:"remove_id,omitempty"`
+}
+
+//unescape returns plain text of the value in a record.
+func unescape(v string) string {
+	return html.UnescapeString(strings.Replace(v, "<br>", "\n", -1))
+}
+
+//newRecordInfo returns recordInfo of rec.
+func newRecordInfo(rec *record.Record) *recordInfo {
+	r := &recordInfo{
+		ID:          rec.ID,
+		Stamp:       rec.Stamp,
+		Name:        unescape(rec.GetBodyValue("name", "")),
+		Mail:        unescape(rec.GetBodyValue("mail", "")),
+		Body:        unescape(rec.GetBodyValue("body", "")),
+		Pubkey:      rec.GetBodyValue("pubkey", ""),
+		SignOK:      rec.CheckSign() == record.SignOK,
+		BaseID:      rec.GetBodyValue("base_id", ""),
+		RemoveStamp: rec.GetBodyValue("remove_stamp", ""),
+		RemoveID:    rec.GetBodyValue("remove_id", ""),
+	}
+	if rec.HasAttach() {
+		suffix := rec.GetBodyValue("suffix", cfg.SuffixTXT)
+		r.Attach = &attachInfo{
+			Suffix: suffix,
+			Size:   rec.AttachSize(),
+			URL:    fmt.Sprintf("%s/%s/%s/%d.%s", cfg.ThreadURL, rec.Datfile, rec.ID, rec.Stamp, suffix),
+		}
+	}
+	return r
+}
+
+//newCGI returns CGI obj if the client is a visitor, or renders 403.
+func newCGI(w http.ResponseWriter, r *http.Request) (*cgi.CGI, error) {
+	c, err := cgi.NewCGI(w, r)
+	if err != nil {
+		renderError(w, http.StatusBadRequest, err.Error())
+		return nil, err
+	}
+	if !c.CheckVisitor() {
+		renderError(w, http.StatusForbidden, "forbidden")
+		return nil, errors.New("visitor not allowed")
+	}
+	return c, nil
+}
+
+//renderError renders the error message in JSON with status code.
+func renderError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(status)
+	err := json.NewEncoder(w).Encode(struct {
+		Error string `json:"error"`
+	}{msg})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+//notModified returns true if the client has the contents with etag
+//or the contents are not modified after If-Modified-Since.
+func notModified(r *http.Request, etag string, modified int64) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, e := range strings.Split(inm, ",") {
+			if e = strings.TrimSpace(e); e == etag || e == "*" {
+				return true
+			}
+		}
+		return false
+	}
+	if modified <= 0 {
+		return false
+	}
+	t, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	return err == nil && modified <= t.Unix()
+}
+
+//render renders v in JSON with ETag and Last-Modified(if modified>0),
+//or renders 304 if the client has the same contents.
+func render(w http.ResponseWriter, r *http.Request, modified int64, v interface{}) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		log.Println(err)
+		renderError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	etag := fmt.Sprintf(`"%x"`, md5.Sum(b))
+	w.Header().Set("ETag", etag)
+	if modified > 0 {
+		w.Header().Set("Last-Modified", time.Unix(modified, 0).UTC().Format(http.TimeFormat))
+	}
+	if notModified(r, etag, modified) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	if r.Method == "HEAD" {
+		return
+	}
+	if _, err := w.Write(b); err != nil {
+		log.Println(err)
+	}
+}
+
+//printThreads renders all threads sorted by stamp or velocity.
+//threads can be filtered by title and tag.
+func printThreads(w http.ResponseWriter, r *http.Request) {
+	if _, err := newCGI(w, r); err != nil {
+		return
+	}
+	filter := strings.ToLower(r.FormValue("filter"))
+	tag := strings.ToLower(r.FormValue("tag"))
+	all := thread.AllCaches()
+	if r.FormValue("sort") == "velocity" {
+		sort.Sort(sort.Reverse(thread.NewSortByVelocity(all)))
+	} else {
+		sort.Sort(sort.Reverse(thread.NewSortByStamp(all, false)))
+	}
+	threads := make([]*threadInfo, 0, len(all))
+	for _, ca := range all {
+		title := util.FileDecode(ca.Datfile)
+		if filter != "" && !strings.Contains(strings.ToLower(title), filter) {
+			continue
+		}
+		if tag != "" && !user.Has(ca.Datfile, tag) {
+			continue
+		}
+		threads = append(threads, newThreadInfo(ca, false))
+	}
+	render(w, r, 0, threads)
+}
+
+//getCache returns the thread in the url, or renders 404.
+func getCache(w http.ResponseWriter, r *http.Request) *thread.Cache {
+	ca := thread.NewCache(mux.Vars(r)["datfile"])
+	if !ca.Exists() {
+		renderError(w, http.StatusNotFound, "thread not found")
+		return nil
+	}
+	return ca
+}
+
+//printThread renders the info of the thread.
+func printThread(w http.ResponseWriter, r *http.Request) {
+	if _, err := newCGI(w, r); err != nil {
+		return
+	}
+	if ca := getCache(w, r); ca != nil {
+		render(w, r, ca.Modified(), newThreadInfo(ca, false))
+	}
+}
+
+//printRecords renders records in the thread with paging.
+//page 0 is the newest records, and records are sorted by stamp in a page.
+func printRecords(w http.ResponseWriter, r *http.Request) {
+	if _, err := newCGI(w, r); err != nil {
+		return
+	}
+	ca := getCache(w, r)
+	if ca == nil {
+		return
+	}
+	page := 0
+	if p := r.FormValue("page"); p != "" {
+		var err error
+		if page, err = strconv.Atoi(p); err != nil || page < 0 {
+			renderError(w, http.StatusBadRequest, "illegal page")
+			return
+		}
+	}
+	recs := ca.LoadRecords(record.Alive)
+	ids := recs.Keys()
+	if last := len(ids) / cfg.ThreadPageSize; page > last {
+		page = last
+	}
+	to := len(ids) - cfg.ThreadPageSize*page
+	from := to - cfg.ThreadPageSize
+	if to < 0 {
+		to = 0
+	}
+	if from < 0 {
+		from = 0
+	}
+	res := struct {
+		*threadInfo
+		Page     int           `json:"page"`
+		Pages    int           `json:"pages"`
+		PageSize int           `json:"page_size"`
+		List     []*recordInfo `json:"list"`
+	}{
+		threadInfo: newThreadInfo(ca, false),
+		Page:       page,
+		Pages:      (len(ids) + cfg.ThreadPageSize - 1) / cfg.ThreadPageSize,
+		PageSize:   cfg.ThreadPageSize,
+		List:       make([]*recordInfo, 0, to-from),
+	}
+	for _, k := range ids[from:to] {
+		rec := recs[k]
+		if err := rec.Load(); err != nil {
+			log.Println(err)
+			continue
+		}
+		res.List = append(res.List, newRecordInfo(rec))
+	}
+	render(w, r, ca.Modified(), res)
+}
+
+//postRecord adds a record in JSON to the thread.
+//if dopost is true broadcasts it.
+func postRecord(w http.ResponseWriter, r *http.Request) {
+	c, err := newCGI(w, r)
+	if err != nil {
+		return
+	}
+	ca := getCache(w, r)
+	if ca == nil {
+		return
+	}
+	var p struct {
+		Body      string `json:"body"`
+		Name      string `json:"name"`
+		Mail      string `json:"mail"`
+		Passwd    string `json:"passwd"`
+		BaseStamp string `json:"base_stamp"`
+		BaseID    string `json:"base_id"`
+		Attach    string `json:"attach"`
+		Suffix    string `json:"suffix"`
+		Dopost    bool   `json:"dopost"`
+	}
+	limit := int64(cfg.RecordLimit) << 11
+	if err = json.NewDecoder(http.MaxBytesReader(w, r.Body, limit)).Decode(&p); err != nil {
+		renderError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if p.Attach != "" {
+		if _, err = base64.StdEncoding.DecodeString(p.Attach); err != nil {
+			renderError(w, http.StatusBadRequest, "attach must be base64 encoded")
+			return
+		}
+		if p.Suffix == "" {
+			p.Suffix = cfg.SuffixTXT
+		}
+	} else {
+		p.Suffix = ""
+	}
+	body := map[string]string{
+		"body":       p.Body,
+		"name":       p.Name,
+		"mail":       p.Mail,
+		"base_stamp": p.BaseStamp,
+		"base_id":    p.BaseID,
+		"attach":     p.Attach,
+		"suffix":     p.Suffix,
+	}
+	rec, err := threadCGI.NewRecord(ca.Datfile, body, p.Passwd, time.Now().Unix())
+	if err != nil {
+		renderError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	log.Printf("post %s/%d_%s from %s via api\n", ca.Datfile, rec.Stamp, rec.ID, c.Req.RemoteAddr)
+	switch err = threadCGI.Post(rec, p.Dopost); err {
+	case nil:
+	case threadCGI.ErrBigRecord:
+		renderError(w, http.StatusRequestEntityTooLarge, err.Error())
+		return
+	case threadCGI.ErrNoThread:
+		renderError(w, http.StatusNotFound, err.Error())
+		return
+	default:
+		renderError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(newRecordInfo(rec)); err != nil {
+		log.Println(err)
+	}
+}
+
+//printRecent renders threads in the recent list with suggested tags.
+func printRecent(w http.ResponseWriter, r *http.Request) {
+	if _, err := newCGI(w, r); err != nil {
+		return
+	}
+	cl := thread.MakeRecentCachelist()
+	threads := make([]*threadInfo, len(cl))
+	for i, ca := range cl {
+		threads[i] = newThreadInfo(ca, true)
+	}
+	render(w, r, 0, threads)
+}
+
+//printTags renders user tags with # of threads which have the tag.
+func printTags(w http.ResponseWriter, r *http.Request) {
+	if _, err := newCGI(w, r); err != nil {
+		return
+	}
+	type tagInfo struct {
+		Tag     string `json:"tag"`
+		Threads int    `json:"threads"`
+	}
+	tags := user.Get()
+	count := make(map[string]int)
+	for _, ca := range thread.AllCaches() {
+		for _, t := range user.GetStrings(ca.Datfile) {
+			count[strings.ToLower(t)]++
+		}
+	}
+	res := make([]*tagInfo, len(tags))
+	for i, t := range tags {
+		res[i] = &tagInfo{t.Tagstr, count[strings.ToLower(t.Tagstr)]}
+	}
+	render(w, r, 0, res)
+}
@@ -31,7 +31,9 @@ package cgi
 import (
 	"fmt"
 	"html/template"
+	"io"
 	"log"
+	"mime"
 	"net/http"
 	"os"
 	"regexp"
@@ -41,6 +43,7 @@ import (
 
 	"github.com/russross/blackfriday"
 	"github.com/shingetsu-gou/shingetsu-gou/cfg"
+	"github.com/shingetsu-gou/shingetsu-gou/record"
 	"github.com/shingetsu-gou/shingetsu-gou/tag"
 	"github.com/shingetsu-gou/shingetsu-gou/tag/suggest"
 	"github.com/shingetsu-gou/shingetsu-gou/tag/user"
@@ -434,6 +437,72 @@ func (c *CGI) bracketLink(link, appli string, absuri bool) string {
 	return "[[" + link + "]]"
 }
 
+//RenderRecord renders record.txt of rec to w.
+//path is the path of the thread page used for links in the record.
+func (c *CGI) RenderRecord(w io.Writer, path string, rec *record.Record) {
+	thumbnailSize := ""
+	var suffix string
+	var attachSize int64
+	if rec.HasAttach() {
+		suffix = rec.GetBodyValue("suffix", "")
+		attachFile := rec.AttachPath("")
+		attachSize = int64(rec.AttachSize())
+		reg := regexp.MustCompile("^[0-9A-Za-z]+")
+		if !reg.MatchString(suffix) {
+			suffix = cfg.SuffixTXT
+		}
+		typ := mime.TypeByExtension("." + suffix)
+		if typ == "" {
+			typ = "text/plain"
+		}
+		if util.IsValidImage(typ, attachFile) {
+			thumbnailSize = cfg.DefaultThumbnailSize
+		}
+	}
+	body := rec.GetBodyValue("body", "")
+	body = c.HTMLFormat(body, cfg.ThreadURL, path, false)
+	removeID := rec.GetBodyValue("remove_id", "")
+	if len(removeID) > 8 {
+		removeID = removeID[:8]
+	}
+	resAnchor := c.ResAnchor(removeID, cfg.ThreadURL, path, false)
+
+	id8 := rec.ID
+	if len(id8) > 8 {
+		id8 = id8[:8]
+	}
+	d := c.Defaults()
+	d.Path = path
+	s := struct {
+		Datfile    string
+		Rec        *record.Record
+		RecHead    record.Head
+		Sid        string
+		AttachSize int64
+		Suffix     string
+		Body       template.HTML
+		Thumbnail  string
+		RemoveID   string
+		ResAnchor  string
+		SignOK     bool
+		Defaults
+	}{
+		rec.Datfile,
+		rec,
+		rec.CopyHead(),
+		id8,
+		attachSize,
+		suffix,
+		template.HTML(body),
+		thumbnailSize,
+		removeID,
+		resAnchor,
+		rec.CheckSign() == record.SignOK,
+		*d,
+	}
+	RenderTemplate("record", s, w)
+}
+
 //RemoveFileForm render remove_form_form page.
 func (c *CGI) RemoveFileForm(ca *thread.Cache, title string) {
 	s := struct {
@@ -540,6 +609,20 @@ func (c *CGI) PrintNewElementForm() {
 	RenderTemplate("new_element_form", s, c.WR)
 }
 
+//PrintSearchForm renders search_form.txt which posts query to action.
+func (c *CGI) PrintSearchForm(action, query string) {
+	d := struct {
+		Query   string
+		Action  string
+		Message Message
+	}{
+		query,
+		action,
+		c.M,
+	}
+	RenderTemplate("search_form", d, c.WR)
+}
+
 //IsBot returns true if client is bot.
 func (c *CGI) IsBot() bool {
 	robots := []string{
@@ -0,0 +1,167 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/shingetsu-gou/shingetsu-gou/cfg"
+	"github.com/shingetsu-gou/shingetsu-gou/hub"
+	"github.com/shingetsu-gou/shingetsu-gou/record"
+	"github.com/shingetsu-gou/shingetsu-gou/thread"
+	"github.com/shingetsu-gou/shingetsu-gou/util"
+)
+
+const (
+	eventBuffer  = 32
+	pingInterval = 30 * time.Second
+)
+
+//nEventClients is # of clients which are receiving events.
+var nEventClients int32
+
+//printEvents streams records added to the thread in the url (/events/<datfile>),
+//or to all threads (/events), as server-sent events.
+func printEvents(w http.ResponseWriter, r *http.Request) {
+	g, err := new(w, r)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	f, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	topic := hub.All
+	var ca *thread.Cache
+	if datfile := strings.TrimPrefix(r.URL.Path, cfg.GatewayURL+"/events/"); datfile != r.URL.Path {
+		ca = thread.NewCache(datfile)
+		if !ca.Exists() {
+			g.Print404(nil, "")
+			return
+		}
+		topic = datfile
+	}
+	if n := atomic.AddInt32(&nEventClients, 1); int(n) > cfg.MaxConnection/4 {
+		atomic.AddInt32(&nEventClients, -1)
+		http.Error(w, "too many clients", http.StatusServiceUnavailable)
+		return
+	}
+	defer atomic.AddInt32(&nEventClients, -1)
+	ch := hub.Subscribe(topic, eventBuffer)
+	defer hub.Unsubscribe(topic, ch)
+
+	w.Header().Set("Content-Type", "text/event-stream; charset=UTF-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	if _, err = fmt.Fprint(w, "retry: 10000\n\n"); err != nil {
+		return
+	}
+	if ca != nil {
+		if err = g.resendEvents(ca, r.Header.Get("Last-Event-ID")); err != nil {
+			return
+		}
+	}
+	f.Flush()
+
+	ping := time.NewTicker(pingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ping.C:
+			_, err = fmt.Fprint(w, ": ping\n\n")
+		case v := <-ch:
+			h := v.(*record.Head)
+			err = g.writeEvent(record.New(h.Datfile, h.ID, h.Stamp))
+		}
+		if err != nil {
+			return
+		}
+		f.Flush()
+	}
+}
+
+//resendEvents writes records in ca newer than the record lastID(stamp_id),
+//which the client received at last before reconnecting.
+func (g *gatewayCGI) resendEvents(ca *thread.Cache, lastID string) error {
+	if lastID == "" {
+		return nil
+	}
+	stamp, err := strconv.ParseInt(strings.Split(lastID, "_")[0], 10, 64)
+	if err != nil {
+		return nil
+	}
+	recs := ca.LoadRecords(record.Alive)
+	for _, k := range recs.Keys() {
+		if rec := recs[k]; rec.Stamp > stamp {
+			if err := g.writeEvent(rec); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+//writeEvent writes the record event with id and html of rec.
+func (g *gatewayCGI) writeEvent(rec *record.Record) error {
+	if err := rec.Load(); err != nil {
+		log.Println(err)
+		return nil
+	}
+	var buf bytes.Buffer
+	g.RenderRecord(&buf, util.FileDecode(rec.Datfile), rec)
+	b, err := json.Marshal(struct {
+		Datfile string `json:"datfile"`
+		Stamp   int64  `json:"stamp"`
+		ID      string `json:"id"`
+		HTML    string `json:"html"`
+	}{
+		rec.Datfile,
+		rec.Stamp,
+		rec.ID,
+		buf.String(),
+	})
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+	_, err = fmt.Fprintf(g.WR, "id: %s\nevent: record\ndata: %s\n\n", rec.Idstr(), b)
+	return err
+}
@@ -65,11 +65,15 @@ func Setup(s *cgi.LoggingServeMux) {
 	s.RegistCompressHandler(cfg.GatewayURL+"/changes", printIndexChanges)
 	s.RegistCompressHandler(cfg.GatewayURL+"/recent", printRecent)
 	s.RegistCompressHandler(cfg.GatewayURL+"/new", printNew)
+	s.RegistCompressHandler(cfg.GatewayURL+"/search", printSearch)
 	s.RegistCompressHandler(cfg.GatewayURL+"/thread", printGatewayThread)
 	s.RegistCompressHandler(cfg.GatewayURL+"/", PrintTitle)
 	s.RegistCompressHandler(cfg.GatewayURL+"/csv/index/", printCSV)
 	s.RegistCompressHandler(cfg.GatewayURL+"/csv/changes/", printCSVChanges)
 	s.RegistCompressHandler(cfg.GatewayURL+"/csv/recent/", printCSVRecent)
+	//not compressed so that events are sent immediately.
+	s.Handle(cfg.GatewayURL+"/events", http.HandlerFunc(printEvents))
+	s.Handle(cfg.GatewayURL+"/events/", http.HandlerFunc(printEvents))
 }
 
 //printGateway just redirects to correspoinding url using thread.cgi.
@@ -230,6 +234,84 @@ func printNew(w http.ResponseWriter, r *http.Request) {
 	g.Footer(nil)
 }
 
+//printSearch renders the form for searching and records which match the query
+//with paging.
+func printSearch(w http.ResponseWriter, r *http.Request) {
+	const snippetLen = 120 //Charactors
+
+	g, err := new(w, r)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	query := g.Req.FormValue("query")
+	page, err := strconv.Atoi(g.Req.FormValue("page"))
+	if err != nil || page < 0 {
+		page = 0
+	}
+	title := g.M["search"]
+	if query != "" {
+		title = fmt.Sprintf("%s: %s", g.M["search"], html.EscapeString(query))
+	}
+	g.Header(title, "", nil, true)
+	fmt.Fprintf(g.WR, "<p>%s</p>", g.M["desc_search"])
+	g.PrintSearchForm(cfg.GatewayURL+"/search", query)
+	if query == "" {
+		g.Footer(nil)
+		return
+	}
+	type item struct {
+		Title   string
+		Sid     string
+		Stamp   int64
+		Snippet string
+	}
+	results := thread.SearchRecords(query)
+	if last := len(results) / cfg.SearchPageSize; page > last {
+		page = last
+	}
+	begin := page * cfg.SearchPageSize
+	end := begin + cfg.SearchPageSize
+	if end > len(results) {
+		end = len(results)
+	}
+	var items []*item
+	for i := begin; i < end; i++ {
+		rec := record.New(results[i].Datfile, results[i].ID, results[i].Stamp)
+		if err := rec.Load(); err != nil {
+			log.Println(err)
+			continue
+		}
+		body := html.UnescapeString(strings.Replace(rec.GetBodyValue("body", ""), "<br>", " ", -1))
+		if b := []rune(body); len(b) > snippetLen {
+			body = string(b[:snippetLen]) + "..."
+		}
+		items = append(items, &item{
+			Title:   util.EscapeSpace(util.FileDecode(rec.Datfile)),
+			Sid:     rec.ID[:8],
+			Stamp:   rec.Stamp,
+			Snippet: body,
+		})
+	}
+	s := struct {
+		Query   string
+		Page    int
+		Hits    int
+		HasNext bool
+		Results []*item
+		cgi.Defaults
+	}{
+		query,
+		page,
+		len(results),
+		end < len(results),
+		items,
+		*g.Defaults(),
+	}
+	cgi.RenderTemplate("search_result", s, g.WR)
+	g.Footer(nil)
+}
+
 //PrintTitle renders list of newer thread in the disk for the top page
 func PrintTitle(w http.ResponseWriter, r *http.Request) {
 	g, err := new(w, r)
@@ -364,7 +446,7 @@ func (g *gatewayCGI) appendRSS(rsss *cgi.RSS, ca *thread.Cache) {
 		}
 		desc := cgi.RSSTextFormat(r.GetBodyValue("body", ""))
 		content := g.rssHTMLFormat(r.GetBodyValue("body", ""), cfg.ThreadURL, title)
-		if attach := r.GetBodyValue("attach", ""); attach != "" {
+		if r.HasAttach() {
 			suffix := r.GetBodyValue("suffix", "")
 			if reg := regexp.MustCompile("^[0-9A-Za-z]+$"); !reg.MatchString(suffix) {
 				suffix = cfg.SuffixTXT
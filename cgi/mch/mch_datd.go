@@ -213,8 +213,9 @@ func (m *mchCGI) threadApp(board, datkey string) {
 	}
 
 	if m.CheckGetCache() {
-		download.GetCache(true, data)
+		download.GetCache(m.Req.Context(), true, data)
 	}
+	data.Touch()
 
 	thread := keylib.MakeDat(data, board, m.Req.Host)
 	str := strings.Join(thread, "\n") + "\n"
@@ -296,7 +297,8 @@ func (m *mchCGI) headApp() {
 }
 
 var (
-	errSpamM = errors.New("this is spam")
+	errSpamM   = errors.New("this is spam")
+	errForgedM = errors.New("signature is invalid")
 )
 
 //postComment creates a record from args and adds it to thread.Cache.
@@ -314,6 +316,9 @@ func (m *mchCGI) postComment(threadKey, name, mail, body, passwd, tag string) er
 	if rec.IsSpam() {
 		return errSpamM
 	}
+	if rec.CheckSign() == record.SignNG {
+		return errForgedM
+	}
 	rec.Sync()
 	if tag != "" {
 		user.Set(c.Datfile, []string{tag})
@@ -428,8 +433,13 @@ func (m *mchCGI) postCommentApp() {
 		m.errorResp("自ノード以外で署名機能は使えません", info)
 	}
 	err := m.postComment(key, name, info["mail"], body, passwd, tag)
-	if err == errSpamM {
+	switch err {
+	case errSpamM:
 		m.errorResp("スパムとみなされました", info)
+		return
+	case errForgedM:
+		m.errorResp("署名が正しくありません", info)
+		return
 	}
 	m.WR.Header().Set("Content-Type", "text/html; charset=Shift_JIS")
 	fmt.Fprintln(m.WR,
@@ -0,0 +1,243 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package server
+
+import (
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/handlers"
+	"github.com/shingetsu-gou/shingetsu-gou/bandwidth"
+	"github.com/shingetsu-gou/shingetsu-gou/cfg"
+)
+
+//sweepInterval is the interval for removing unused buckets and offenders.
+const sweepInterval = time.Minute
+
+//errResponseTooLarge is returned when writing a response over MaxResponseSize.
+var errResponseTooLarge = errors.New("response is too large")
+
+//bucket is a token bucket for requests.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+//take refills tokens by rate per second up to burst since the last time,
+//and takes one token. returns the time to wait if no token is left.
+func (b *bucket) take(now time.Time, rate, burst float64) (bool, time.Duration) {
+	if b.last.IsZero() {
+		b.tokens = burst
+	} else if d := now.Sub(b.last).Seconds(); d > 0 {
+		b.tokens += d * rate
+		if b.tokens > burst {
+			b.tokens = burst
+		}
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / rate * float64(time.Second))
+	}
+	b.tokens--
+	return true, 0
+}
+
+//offender is a remote IP which violated the protocol.
+type offender struct {
+	violations int
+	last       time.Time
+	until      time.Time //banned until
+}
+
+//limiter limits requests per remote IP and method, and bans IPs which violate the protocol.
+type limiter struct {
+	sync.Mutex
+	buckets   map[string]*bucket
+	offenders map[string]*offender
+	swept     time.Time
+}
+
+var limits = newLimiter()
+
+//newLimiter returns an empty limiter.
+func newLimiter() *limiter {
+	return &limiter{
+		buckets:   make(map[string]*bucket),
+		offenders: make(map[string]*offender),
+	}
+}
+
+//rate returns # of requests per second and burst for the method.
+//join pings back the remote node, so it is limited by JoinRateLimit.
+func rate(method string) (float64, float64) {
+	if method == "join" {
+		r := float64(cfg.JoinRateLimit)
+		if r < 1 {
+			r = 1
+		}
+		return r / 60, r
+	}
+	return float64(cfg.RateLimit) / 60, float64(cfg.RateBurst)
+}
+
+//allow returns true if a request of the method from ip is allowed at now.
+//if not, returns the time to wait.
+func (l *limiter) allow(ip, method string, now time.Time) (bool, time.Duration) {
+	l.Lock()
+	defer l.Unlock()
+	l.sweep(now)
+	if o, exist := l.offenders[ip]; exist && now.Before(o.until) {
+		return false, o.until.Sub(now)
+	}
+	if cfg.RateLimit <= 0 {
+		return true, 0
+	}
+	key := ip + " " + method
+	b, exist := l.buckets[key]
+	if !exist {
+		b = &bucket{}
+		l.buckets[key] = b
+	}
+	r, burst := rate(method)
+	return b.take(now, r, burst)
+}
+
+//violate records that ip violated the protocol at now,
+//and bans ip for BanTime if violated BanViolations times within BanTime.
+func (l *limiter) violate(ip string, now time.Time) {
+	if cfg.BanViolations <= 0 {
+		return
+	}
+	l.Lock()
+	defer l.Unlock()
+	o, exist := l.offenders[ip]
+	if !exist {
+		o = &offender{}
+		l.offenders[ip] = o
+	}
+	banTime := time.Duration(cfg.BanTime) * time.Second
+	if now.Sub(o.last) > banTime {
+		o.violations = 0
+	}
+	o.violations++
+	o.last = now
+	if o.violations >= cfg.BanViolations {
+		log.Println(ip, "is banned for", banTime)
+		o.until = now.Add(banTime)
+		o.violations = 0
+	}
+}
+
+//sweep removes buckets unused for a while and expired offenders once in sweepInterval.
+func (l *limiter) sweep(now time.Time) {
+	if now.Sub(l.swept) < sweepInterval {
+		return
+	}
+	l.swept = now
+	for k, b := range l.buckets {
+		if now.Sub(b.last) > 10*sweepInterval {
+			delete(l.buckets, k)
+		}
+	}
+	banTime := time.Duration(cfg.BanTime) * time.Second
+	for k, o := range l.offenders {
+		if now.After(o.until) && now.Sub(o.last) > banTime {
+			delete(l.offenders, k)
+		}
+	}
+}
+
+//remoteHost returns the IP of the remote address of r.
+func remoteHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+//refuse responds 429 Too Many Requests with Retry-After header.
+func refuse(w http.ResponseWriter, wait time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(wait/time.Second)+1))
+	http.Error(w, "too many requests", http.StatusTooManyRequests)
+}
+
+//limited returns the handler which refuses requests by 429 Too Many Requests
+//if the remote IP is banned or requests the method too frequently, or
+//the method is heavy and the upload is over the hard cap.
+//the response is compressed, capped by MaxResponseSize and counted as upload.
+func limited(method string, fn func(w http.ResponseWriter, r *http.Request)) http.Handler {
+	h := handlers.CompressHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if cfg.MaxResponseSize > 0 {
+			w = &cappedWriter{ResponseWriter: w, left: cfg.MaxResponseSize}
+		}
+		fn(w, r)
+	}))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ok, wait := limits.allow(remoteHost(r), method, time.Now()); !ok {
+			log.Println(r.RemoteAddr, "is refused to", method)
+			refuse(w, wait)
+			return
+		}
+		if bandwidth.Heavy(method) && bandwidth.State(bandwidth.Upload) == bandwidth.Capped {
+			log.Println(r.RemoteAddr, "is refused to", method, "by", bandwidth.ErrCapped)
+			refuse(w, bandwidth.Reset(bandwidth.Upload))
+			return
+		}
+		h.ServeHTTP(bandwidth.NewResponseWriter(w), r)
+	})
+}
+
+//cappedWriter is ResponseWriter which refuses to write over the size.
+type cappedWriter struct {
+	http.ResponseWriter
+	left int64
+}
+
+//Write writes p if the size is left, or returns errResponseTooLarge without writing.
+func (c *cappedWriter) Write(p []byte) (int, error) {
+	if int64(len(p)) > c.left {
+		c.left = 0
+		return 0, errResponseTooLarge
+	}
+	c.left -= int64(len(p))
+	return c.ResponseWriter.Write(p)
+}
+
+//Flush flushes the response if possible.
+func (c *cappedWriter) Flush() {
+	if f, ok := c.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
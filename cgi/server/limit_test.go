@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shingetsu-gou/shingetsu-gou/cfg"
+)
+
+func TestLimiter(t *testing.T) {
+	cfg.RateLimit = 60
+	cfg.RateBurst = 3
+	cfg.JoinRateLimit = 1
+	cfg.BanTime = 60
+	cfg.BanViolations = 2
+	l := newLimiter()
+	now := time.Unix(1000000, 0)
+	for i := 0; i < 3; i++ {
+		if ok, _ := l.allow("192.0.2.1", "get", now); !ok {
+			t.Fatal("refused in burst", i)
+		}
+	}
+	ok, wait := l.allow("192.0.2.1", "get", now)
+	if ok || wait != time.Second {
+		t.Fatal("allowed over burst", wait)
+	}
+	if ok, _ := l.allow("192.0.2.1", "head", now); !ok {
+		t.Fatal("other method is refused")
+	}
+	if ok, _ := l.allow("192.0.2.2", "get", now); !ok {
+		t.Fatal("other IP is refused")
+	}
+	if ok, _ := l.allow("192.0.2.1", "get", now.Add(time.Second)); !ok {
+		t.Fatal("not refilled")
+	}
+	if ok, _ := l.allow("192.0.2.1", "join", now); !ok {
+		t.Fatal("refused first join")
+	}
+	if ok, _ := l.allow("192.0.2.1", "join", now.Add(30*time.Second)); ok {
+		t.Fatal("allowed second join")
+	}
+
+	l.violate("192.0.2.2", now)
+	if ok, _ := l.allow("192.0.2.2", "ping", now); !ok {
+		t.Fatal("banned by one violation")
+	}
+	l.violate("192.0.2.2", now.Add(time.Second))
+	ok, wait = l.allow("192.0.2.2", "ping", now.Add(2*time.Second))
+	if ok || wait != 59*time.Second {
+		t.Fatal("not banned", wait)
+	}
+	if ok, _ := l.allow("192.0.2.2", "ping", now.Add(62*time.Second)); !ok {
+		t.Fatal("ban is not expired")
+	}
+}
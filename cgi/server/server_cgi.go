@@ -29,7 +29,9 @@
 package server
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"math"
@@ -40,7 +42,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/shingetsu-gou/go-nat"
 	"github.com/shingetsu-gou/shingetsu-gou/cfg"
 	"github.com/shingetsu-gou/shingetsu-gou/cgi"
 	"github.com/shingetsu-gou/shingetsu-gou/node"
@@ -50,26 +51,34 @@ import (
 	"github.com/shingetsu-gou/shingetsu-gou/tag/user"
 	"github.com/shingetsu-gou/shingetsu-gou/thread"
 	"github.com/shingetsu-gou/shingetsu-gou/updateque"
+	"github.com/shingetsu-gou/shingetsu-gou/util"
 )
 
-//Setup setups handlers for server.cgi
+//capabilities is extensions of server.cgi supported by Gou.
+const capabilities = "summary bulk"
+
+//Setup setups handlers for server.cgi.
+//requests are limited per remote IP and method.
 func Setup(s *cgi.LoggingServeMux) {
-	s.RegistCompressHandler(cfg.ServerURL+"/ping", doPing)
-	s.RegistCompressHandler(cfg.ServerURL+"/node", doNode)
-	s.RegistCompressHandler(cfg.ServerURL+"/join/", doJoin)
-	s.RegistCompressHandler(cfg.ServerURL+"/bye/", doBye)
-	s.RegistCompressHandler(cfg.ServerURL+"/have/", doHave)
-	s.RegistCompressHandler(cfg.ServerURL+"/get/", doGetHead)
-	s.RegistCompressHandler(cfg.ServerURL+"/head/", doGetHead)
-	s.RegistCompressHandler(cfg.ServerURL+"/update/", doUpdate)
-	s.RegistCompressHandler(cfg.ServerURL+"/recent/", doRecent)
-	s.RegistCompressHandler(cfg.ServerURL+"/", doMotd)
+	s.Handle(cfg.ServerURL+"/ping", limited("ping", doPing))
+	s.Handle(cfg.ServerURL+"/node", limited("node", doNode))
+	s.Handle(cfg.ServerURL+"/join/", limited("join", doJoin))
+	s.Handle(cfg.ServerURL+"/bye/", limited("bye", doBye))
+	s.Handle(cfg.ServerURL+"/have/", limited("have", doHave))
+	s.Handle(cfg.ServerURL+"/get/", limited("get", doGetHead))
+	s.Handle(cfg.ServerURL+"/head/", limited("head", doGetHead))
+	s.Handle(cfg.ServerURL+"/summary/", limited("summary", doSummary))
+	s.Handle(cfg.ServerURL+"/bulk", limited("bulk", doBulk))
+	s.Handle(cfg.ServerURL+"/update/", limited("update", doUpdate))
+	s.Handle(cfg.ServerURL+"/recent/", limited("recent", doRecent))
+	s.Handle(cfg.ServerURL+"/", limited("motd", doMotd))
 
 }
 
-//doPing just resopnse PONG with remote addr.
+//doPing just resopnse PONG with remote addr, and tells capabilities in the header.
 func doPing(w http.ResponseWriter, r *http.Request) {
 	log.Println(r.Header)
+	w.Header().Set(node.CapabilityHeader, capabilities)
 	host, _, err := net.SplitHostPort(r.RemoteAddr)
 	if err != nil {
 		log.Println(err)
@@ -146,6 +155,7 @@ func doHave(w http.ResponseWriter, r *http.Request) {
 	if m == nil {
 		fmt.Fprintln(w, "NO")
 		log.Println("illegal url")
+		s.violate()
 		return
 	}
 	ca := thread.NewCache(m[1])
@@ -169,17 +179,20 @@ func doUpdate(w http.ResponseWriter, r *http.Request) {
 	m := reg.FindStringSubmatch(s.Path())
 	if m == nil {
 		log.Println("illegal url")
+		s.violate()
 		return
 	}
 	datfile, stamp, id, hostport, path := m[1], m[2], m[3], m[4], m[5]
 	host, portstr, err := net.SplitHostPort(hostport)
 	if err != nil {
 		log.Println(err)
+		s.violate()
 		return
 	}
 	port, err := strconv.Atoi(portstr)
 	if err != nil {
 		log.Println(err)
+		s.violate()
 		return
 	}
 	host = s.remoteIP(host)
@@ -189,7 +202,12 @@ func doUpdate(w http.ResponseWriter, r *http.Request) {
 	}
 
 	n, err := node.MakeNode(host, path, port)
-	if err != nil || !n.IsAllowed() {
+	if err != nil {
+		log.Println(err)
+		s.violate()
+		return
+	}
+	if !n.IsAllowed() {
 		log.Println("detects spam")
 		return
 	}
@@ -219,6 +237,7 @@ func doRecent(w http.ResponseWriter, r *http.Request) {
 	m := reg.FindStringSubmatch(s.Path())
 	if m == nil {
 		log.Println("illegal url")
+		s.violate()
 		return
 	}
 	stamp := m[1]
@@ -236,6 +255,7 @@ func doRecent(w http.ResponseWriter, r *http.Request) {
 		_, err := fmt.Fprintf(w, "%s\n", cont)
 		if err != nil {
 			log.Println(err)
+			return
 		}
 	}
 }
@@ -262,6 +282,7 @@ func doGetHead(w http.ResponseWriter, r *http.Request) {
 	m := reg.FindStringSubmatch(s.Path())
 	if m == nil {
 		log.Println("illegal url", s.Path())
+		s.violate()
 		return
 	}
 	method, datfile, stamp := m[1], m[2], m[3]
@@ -276,14 +297,20 @@ func doGetHead(w http.ResponseWriter, r *http.Request) {
 	for _, r := range recs {
 		if r.InRange(begin, end, id) {
 			if method == "get" {
-				if err := r.Load(); err != nil {
+				if err := r.LoadInline(); err != nil {
 					log.Println(err)
 					continue
 				}
-				fmt.Fprintln(s.WR, r.Recstr())
+				if _, err := fmt.Fprintln(s.WR, r.Recstr()); err != nil {
+					log.Println(err)
+					break
+				}
 				continue
 			}
-			fmt.Fprintln(s.WR, strings.Replace(r.Idstr(), "_", "<>", -1))
+			if _, err := fmt.Fprintln(s.WR, strings.Replace(r.Idstr(), "_", "<>", -1)); err != nil {
+				log.Println(err)
+				break
+			}
 		}
 	}
 	if method == "get" {
@@ -291,6 +318,87 @@ func doGetHead(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+//doSummary returns summaries of records in the range in url (/summary/<datfile>/<begin>-<end>),
+//which is splitted into buckets by # of records.
+func doSummary(w http.ResponseWriter, r *http.Request) {
+	const buckets = 16
+	s, err := new(w, r)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	reg := regexp.MustCompile(`^summary/([0-9A-Za-z_]+)/?([-0-9]*)$`)
+	m := reg.FindStringSubmatch(s.Path())
+	if m == nil {
+		log.Println("illegal url", s.Path())
+		s.violate()
+		return
+	}
+	ca := thread.NewCache(m[1])
+	begin, end, _ := s.parseStamp(m[2], math.MaxInt32)
+	for _, b := range ca.NewSummarizer(begin, end).Split(begin, end, buckets) {
+		fmt.Fprintln(s.WR, b)
+	}
+}
+
+//errTooManyThreads is returned if too many threads are requested by bulk.
+var errTooManyThreads = errors.New("too many threads")
+
+//doBulk returns records in many threads at once. the request is POSTed with lines of
+//"<datfile> <begin>-<end>", and the response is lines of "<datfile><><record>".
+func doBulk(w http.ResponseWriter, r *http.Request) {
+	const (
+		maxThreads = 1000
+		maxBody    = 1 << 20
+	)
+	s, err := new(w, r)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if r.Method != http.MethodPost {
+		s.violate()
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	reg := regexp.MustCompile(`^([0-9A-Za-z_]+) ([-0-9]*)$`)
+	f, _ := w.(http.Flusher)
+	n := 0
+	err = util.EachIOLine(ioutil.NopCloser(io.LimitReader(r.Body, maxBody)), func(line string, i int) error {
+		m := reg.FindStringSubmatch(line)
+		if m == nil {
+			return nil
+		}
+		if n++; n > maxThreads {
+			return errTooManyThreads
+		}
+		ca := thread.NewCache(m[1])
+		begin, end, _ := s.parseStamp(m[2], math.MaxInt32)
+		for _, rec := range ca.LoadRecords(record.Alive) {
+			if !rec.InRange(begin, end, "") {
+				continue
+			}
+			if err := rec.LoadInline(); err != nil {
+				log.Println(err)
+				continue
+			}
+			if _, err := fmt.Fprintln(s.WR, ca.Datfile+"<>"+rec.Recstr()); err != nil {
+				return err
+			}
+		}
+		if f != nil {
+			f.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		log.Println(err)
+		if err == errTooManyThreads {
+			s.violate()
+		}
+	}
+}
+
 //serverCGI is for server.cgi handler.
 type serverCGI struct {
 	*cgi.CGI
@@ -308,13 +416,19 @@ func new(w http.ResponseWriter, r *http.Request) (*serverCGI, error) {
 
 	if w != nil {
 		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set(node.CapabilityHeader, capabilities)
 	}
 
 	return &a, nil
 }
 
+//violate records that the remote node violated the protocol.
+func (s *serverCGI) violate() {
+	limits.violate(remoteHost(s.Req), time.Now())
+}
+
 //remoteIP returns host if host!=""
-//else returns remoteaddr
+//else returns remoteaddr, which must be global unless LAN discovery is enabled.
 func (s *serverCGI) remoteIP(host string) string {
 	if host != "" {
 		return host
@@ -324,7 +438,7 @@ func (s *serverCGI) remoteIP(host string) string {
 		log.Println(err)
 		return ""
 	}
-	if !isGlobal(remoteAddr) {
+	if !cfg.LANDiscovery && !isGlobal(remoteAddr) {
 		log.Println(remoteAddr, "is local IP")
 		return ""
 	}
@@ -337,7 +451,7 @@ func isGlobal(remoteAddr string) bool {
 		log.Println(remoteAddr, "has illegal format")
 		return false
 	}
-	return nat.IsGlobalIP(ip) != ""
+	return util.IsGlobalIP(ip)
 }
 
 //checkRemote returns remoteaddr
@@ -357,7 +471,7 @@ func (s *serverCGI) checkRemote(host string) string {
 		return ""
 	}
 	for _, ipa := range ipaddr {
-		if ipa.String() == remoteAddr {
+		if ipa.Equal(net.ParseIP(remoteAddr)) {
 			return remoteAddr
 		}
 	}
@@ -370,17 +484,20 @@ func (s *serverCGI) extractHost(method string) (string, string, int) {
 	m := reg.FindStringSubmatch(s.Path())
 	if m == nil {
 		log.Println("illegal url")
+		s.violate()
 		return "", "", 0
 	}
 	path := m[2]
 	host, portstr, err := net.SplitHostPort(m[1])
 	if err != nil {
 		log.Println(err)
+		s.violate()
 		return "", "", 0
 	}
 	port, err := strconv.Atoi(portstr)
 	if err != nil {
 		log.Println(err)
+		s.violate()
 		return "", "", 0
 	}
 	return host, path, port
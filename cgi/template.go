@@ -61,6 +61,7 @@ var funcMap = map[string]interface{}{
 	"strEncode":    util.StrEncode,
 	"escape":       util.Escape,
 	"escapeSpace":  util.EscapeSpace,
+	"fileDecode":   util.FileDecode,
 	"localtime":    func(stamp int64) string { return time.Unix(stamp, 0).Format("2006-01-02 15:04") },
 }
 
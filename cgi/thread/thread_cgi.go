@@ -29,6 +29,7 @@
 package thread
 
 import (
+	"bytes"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -254,19 +255,22 @@ func (t *threadCGI) printThreadHead(path, id string, page int, ca *thread.Cache,
 	switch {
 	case ca.HasRecord():
 		if !t.IsBot() {
-			download.GetCache(true, ca)
+			download.GetCache(t.Req.Context(), true, ca)
 		} else {
 			log.Println("bot detected, not get cache")
 		}
 	case t.CheckGetCache():
 		ca.Subscribe()
 		if t.Req.FormValue("search_new_file") == "" {
-			download.GetCache(true, ca)
+			download.GetCache(t.Req.Context(), true, ca)
 		}
 	default:
 		t.Print404(nil, id)
 		return errors.New("no records")
 	}
+	if !t.IsBot() {
+		ca.Touch()
+	}
 	var access string
 	var newcookie []*http.Cookie
 	if ca.HasRecord() && id == "" && page == 0 {
@@ -334,7 +338,7 @@ func (t *threadCGI) printThreadBody(id string, nPage int, ca *thread.Cache) {
 	for _, k := range inrange {
 		rec := recs.Get(k, nil)
 		if (id == "" || rec.ID[:8] == id) && rec.Load() == nil {
-			t.printRecord(ca, rec)
+			t.RenderRecord(t.WR, t.Path(), rec)
 		}
 	}
 
@@ -396,73 +400,12 @@ func (t *threadCGI) printThreadAjax(id string) {
 	recs := ca.LoadRecords(record.Alive)
 	for _, rec := range recs {
 		if id == "" || rec.ID[:8] == id && rec.Load() == nil {
-			t.printRecord(ca, rec)
+			t.RenderRecord(t.WR, t.Path(), rec)
 		}
 	}
 	fmt.Fprintln(t.WR, "</dl>")
 }
 
-//printRecord renders record.txt , with records in cache ca.
-func (t *threadCGI) printRecord(ca *thread.Cache, rec *record.Record) {
-	thumbnailSize := ""
-	var suffix string
-	var attachSize int64
-	if at := rec.GetBodyValue("attach", ""); at != "" {
-		suffix = rec.GetBodyValue("suffix", "")
-		attachFile := rec.AttachPath("")
-		attachSize = int64(len(at)*57/78) + 1000
-		reg := regexp.MustCompile("^[0-9A-Za-z]+")
-		if !reg.MatchString(suffix) {
-			suffix = cfg.SuffixTXT
-		}
-		typ := mime.TypeByExtension("." + suffix)
-		if typ == "" {
-			typ = "text/plain"
-		}
-		if util.IsValidImage(typ, attachFile) {
-			thumbnailSize = cfg.DefaultThumbnailSize
-		}
-	}
-	body := rec.GetBodyValue("body", "")
-	body = t.HTMLFormat(body, cfg.ThreadURL, t.Path(), false)
-	removeID := rec.GetBodyValue("remove_id", "")
-	if len(removeID) > 8 {
-		removeID = removeID[:8]
-	}
-	resAnchor := t.ResAnchor(removeID, cfg.ThreadURL, t.Path(), false)
-
-	id8 := rec.ID
-	if len(id8) > 8 {
-		id8 = id8[:8]
-	}
-	s := struct {
-		Datfile    string
-		Rec        *record.Record
-		RecHead    record.Head
-		Sid        string
-		AttachSize int64
-		Suffix     string
-		Body       template.HTML
-		Thumbnail  string
-		RemoveID   string
-		ResAnchor  string
-		cgi.Defaults
-	}{
-		ca.Datfile,
-		rec,
-		rec.CopyHead(),
-		id8,
-		attachSize,
-		suffix,
-		template.HTML(body),
-		thumbnailSize,
-		removeID,
-		resAnchor,
-		*t.Defaults(),
-	}
-	cgi.RenderTemplate("record", s, t.WR)
-}
-
 //printPostForm renders post_form.txt,page for posting attached file.
 func (t *threadCGI) printPostForm(ca *thread.Cache) {
 	mimes := []string{
@@ -484,6 +427,7 @@ func (t *threadCGI) printPostForm(ca *thread.Cache) {
 }
 
 //renderAttach render the content of attach file with content-type=typ.
+//thumbnails are read from the cache on disk.
 func (t *threadCGI) renderAttach(rec *record.Record, suffix string, stamp int64, thumbnailSize string) {
 	attachFile := rec.AttachPath(thumbnailSize)
 	if attachFile == "" {
@@ -494,24 +438,25 @@ func (t *threadCGI) renderAttach(rec *record.Record, suffix string, stamp int64,
 		typ = "text/plain"
 	}
 	t.WR.Header().Set("Content-Type", typ)
-	t.WR.Header().Set("Last-Modified", t.RFC822Time(stamp))
 	if !util.IsValidImage(typ, attachFile) {
 		t.WR.Header().Set("Content-Disposition", "attachment")
 	}
-	decoded, err := base64.StdEncoding.DecodeString(rec.GetBodyValue("attach", ""))
-	if err != nil {
-		log.Println(err)
-		t.Print404(nil, "")
-		return
-	}
+	var data []byte
+	var err error
 	if thumbnailSize != "" && (cfg.ForceThumbnail || thumbnailSize == cfg.DefaultThumbnailSize) {
-		decoded = util.MakeThumbnail(decoded, suffix, thumbnailSize)
+		data, err = rec.Thumbnail(thumbnailSize)
+	} else {
+		data, err = rec.AttachData()
 	}
-	_, err = t.WR.Write(decoded)
 	if err != nil {
 		log.Println(err)
 		t.Print404(nil, "")
+		return
 	}
+	//contents of the url never change because id is md5 of the record.
+	t.WR.Header().Set("ETag", `"`+attachFile+`"`)
+	t.WR.Header().Set("Cache-Control", "public, max-age=86400")
+	http.ServeContent(t.WR, t.Req, attachFile, time.Unix(stamp, 0), bytes.NewReader(data))
 }
 
 //printAttach renders the content of attach file and makes thumnail if needed and possible.
@@ -520,7 +465,7 @@ func (t *threadCGI) printAttach(datfile, id string, stamp int64, thumbnailSize,
 	switch {
 	case ca.HasRecord():
 	case t.CheckGetCache():
-		download.GetCache(true, ca)
+		download.GetCache(t.Req.Context(), true, ca)
 	default:
 		t.Print404(ca, "")
 		return
@@ -576,30 +521,80 @@ func (t *threadCGI) guessSuffix(at *attached) string {
 func (t *threadCGI) makeRecord(at *attached, suffix string, ca *thread.Cache) (*record.Record, error) {
 	body := make(map[string]string)
 	for _, name := range []string{"body", "base_stamp", "base_id", "name", "mail"} {
-		if value := t.Req.FormValue(name); value != "" {
-			body[name] = util.Escape(value)
-		}
+		body[name] = t.Req.FormValue(name)
 	}
-
 	if at != nil {
 		body["attach"] = at.Data
-		body["suffix"] = strings.TrimSpace(suffix)
-	}
-	if len(body) == 0 {
-		t.Header(t.M["null_article"], "", nil, true)
-		t.Footer(nil)
-		return nil, errors.New("null article")
+		body["suffix"] = suffix
 	}
 	stamp := time.Now().Unix()
 	if t.Req.FormValue("error") != "" {
 		stamp = t.errorTime()
 	}
-	rec := record.New(ca.Datfile, "", 0)
-	passwd := t.Req.FormValue("passwd")
-	rec.Build(stamp, body, passwd)
+	rec, err := NewRecord(ca.Datfile, body, t.Req.FormValue("passwd"), stamp)
+	if err != nil {
+		t.Header(t.M["null_article"], "", nil, true)
+		t.Footer(nil)
+		return nil, err
+	}
+	return rec, nil
+}
+
+//NewRecord builds and returns record in datfile from values in body,
+//which are escaped here. returns ErrNullArticle if body is empty.
+func NewRecord(datfile string, body map[string]string, passwd string, stamp int64) (*record.Record, error) {
+	b := make(map[string]string)
+	for name, value := range body {
+		if value == "" {
+			continue
+		}
+		switch name {
+		case "attach":
+			b[name] = value
+		case "suffix":
+			b[name] = strings.TrimSpace(value)
+		default:
+			b[name] = util.Escape(value)
+		}
+	}
+	if len(b) == 0 {
+		return nil, ErrNullArticle
+	}
+	rec := record.New(datfile, "", 0)
+	rec.Build(stamp, b, passwd)
 	return rec, nil
 }
 
+//errors of posting a record.
+var (
+	ErrNullArticle = errors.New("null article")
+	ErrBigRecord   = errors.New("record is too big")
+	ErrNoThread    = errors.New("thread not found")
+)
+
+//Post checks the record rec and adds it to its thread.
+//if dopost is true broadcasts it.
+func Post(rec *record.Record, dopost bool) error {
+	if len(rec.Recstr()) > cfg.RecordLimit<<10 {
+		return ErrBigRecord
+	}
+	if rec.IsSpam() {
+		return cfg.ErrSpam
+	}
+	if rec.CheckSign() == record.SignNG {
+		return cfg.ErrForged
+	}
+	if !thread.NewCache(rec.Datfile).Exists() {
+		return ErrNoThread
+	}
+	rec.Sync()
+	if dopost {
+		log.Println(rec.Datfile, rec.ID, "is queued")
+		go updateque.UpdateNodes(rec, nil)
+	}
+	return nil
+}
+
 //doPost parses multipart form ,makes record of it and adds to cache.
 //if form dopost=yes broadcasts it.
 func (t *threadCGI) doPost() string {
@@ -616,29 +611,24 @@ func (t *threadCGI) doPost() string {
 	proxyClient := t.Req.Header.Get("X_FORWARDED_FOR")
 	log.Printf("post %s/%d_%s from %s/%s\n", ca.Datfile, ca.Stamp(), rec.ID, t.Req.RemoteAddr, proxyClient)
 
-	if len(rec.Recstr()) > cfg.RecordLimit<<10 {
+	switch err := Post(rec, t.Req.FormValue("dopost") != ""); err {
+	case nil:
+	case ErrBigRecord:
 		t.Header(t.M["big_file"], "", nil, true)
 		t.Footer(nil)
 		return ""
-	}
-	if rec.IsSpam() {
+	case cfg.ErrSpam:
 		t.Header(t.M["spam"], "", nil, true)
 		t.Footer(nil)
 		return ""
-	}
-
-	if ca.Exists() {
-		rec.Sync()
-	} else {
+	case cfg.ErrForged:
+		t.Header(t.M["forged_sign"], "", nil, true)
+		t.Footer(nil)
+		return ""
+	default:
 		t.Print404(nil, "")
 		return ""
 	}
-
-	if t.Req.FormValue("dopost") != "" {
-		log.Println(rec.Datfile, rec.ID, "is queued")
-		go updateque.UpdateNodes(rec, nil)
-	}
-
 	return rec.ID[:8]
 
 }
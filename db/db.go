@@ -35,6 +35,7 @@ import (
 	"fmt"
 	"log"
 	"path"
+	"time"
 
 	"encoding/json"
 
@@ -54,7 +55,20 @@ sugtag Thread json(map[tags]struct{})
 usertag Thread json(map[tags]struct{})
 usertagTag Tag json(map[threads]struct{})
 recent thread:stamp:hash json(Datfile,Stamp.ID)
-record thread:stamp:hash json(Datfile,Stamp.ID,Body,Deleted)
+record thread:stamp:hash json(Datfile,Stamp.ID,Body,Deleted,Forged,Attach)
+blob sha256 attached file
+blobRef sha256 # of records referring the blob
+wordIndex word:thread:stamp:hash json(Datfile,Stamp,ID,Pos)
+wordDF word #records
+threadMeta Thread json(Stamp,Alive,Removed,Size,Hist,Updated)
+threadAccess Thread time when the thread was viewed last
+evicted Thread time when the thread was evicted by the storage quota
+meta "version" schema version
+updateQue thread:stamp:hash:node json(Update)
+updated thread:stamp:hash time
+pendingRemoval thread:stamp:hash(target):thread:stamp:hash(notice) json(Datfile,Stamp,ID,Received) of the notice received before the target
+nodeHealth Addr json(Health)
+bandwidth direction:day(or month) bytes
 
 
 var tables = []string{
@@ -71,12 +85,20 @@ var tables = []string{
 //DB is bolt.DB for operating database.
 var DB *bolt.DB
 
-//Setup setups db.
-func Setup() {
+//Open opens db.
+func Open() {
 	dbpath := path.Join(cfg.RunDir, "gou_bolt.db")
 	var err error
-	DB, err = bolt.Open(dbpath, 0644, nil)
+	DB, err = bolt.Open(dbpath, 0644, &bolt.Options{Timeout: 5 * time.Second})
 	if err != nil {
+		log.Fatal(err, ", maybe the daemon is running")
+	}
+}
+
+//Setup opens db and migrates it to the latest schema.
+func Setup() {
+	Open()
+	if err := Migrate(false); err != nil {
 		log.Fatal(err)
 	}
 }
@@ -0,0 +1,179 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package db
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"path"
+
+	"github.com/boltdb/bolt"
+	"github.com/shingetsu-gou/shingetsu-gou/cfg"
+)
+
+//migration represents one step to migrate the db schema to version.
+type migration struct {
+	version int
+	name    string
+	migrate func(*bolt.Tx) error
+}
+
+var migrations []*migration
+
+//errDryRun is for rollbacking transactions in dry-run mode.
+var errDryRun = errors.New("dry run")
+
+//RegistMigration registers a function which migrates the db to version.
+//migrations are run in order of versions by Setup.
+//should be called in init().
+func RegistMigration(version int, name string, f func(*bolt.Tx) error) {
+	for _, m := range migrations {
+		if m.version == version {
+			log.Fatal("migration version", version, "is already registered")
+		}
+	}
+	m := &migration{
+		version: version,
+		name:    name,
+		migrate: f,
+	}
+	i := len(migrations)
+	for i > 0 && migrations[i-1].version > version {
+		i--
+	}
+	migrations = append(migrations, nil)
+	copy(migrations[i+1:], migrations[i:])
+	migrations[i] = m
+}
+
+//LatestVersion returns the version of the latest schema.
+func LatestVersion() int {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].version
+}
+
+//SchemaVersion returns the version of the db schema.
+func SchemaVersion(tx *bolt.Tx) int {
+	var v int
+	if _, err := Get(tx, "meta", []byte("version"), &v); err != nil {
+		return 0
+	}
+	return v
+}
+
+//setSchemaVersion saves the version of the db schema.
+func setSchemaVersion(tx *bolt.Tx, v int) error {
+	return Put(tx, "meta", []byte("version"), v)
+}
+
+//isEmpty returns true if the db has no buckets.
+func isEmpty(tx *bolt.Tx) bool {
+	empty := true
+	err := tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+		empty = false
+		return nil
+	})
+	if err != nil {
+		log.Println(err)
+	}
+	return empty
+}
+
+//backup copies the db to run dir with the version as suffix.
+func backup(version int) error {
+	return DB.View(func(tx *bolt.Tx) error {
+		fname := path.Join(cfg.RunDir, fmt.Sprintf("gou_bolt.db.v%d.bak", version))
+		log.Println("backing up db to", fname)
+		return tx.CopyFile(fname, 0644)
+	})
+}
+
+//Migrate runs migrations whose versions are newer than the db schema.
+//each migration is run in one transaction with updating the version.
+//if dryRun, runs all migrations in one transaction and rollbacks it.
+//the db is backed up before migrating if not dryRun.
+func Migrate(dryRun bool) error {
+	var version int
+	var empty bool
+	err := DB.View(func(tx *bolt.Tx) error {
+		version = SchemaVersion(tx)
+		empty = isEmpty(tx)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	var pending []*migration
+	for _, m := range migrations {
+		if m.version > version {
+			pending = append(pending, m)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	log.Println("db schema version is", version, ", latest is", LatestVersion())
+	if dryRun {
+		err = DB.Update(func(tx *bolt.Tx) error {
+			for _, m := range pending {
+				log.Println("migrating to version", m.version, ":", m.name, "(dry run)")
+				if err := m.migrate(tx); err != nil {
+					return fmt.Errorf("migration to version %d failed: %s", m.version, err)
+				}
+			}
+			return errDryRun
+		})
+		if err == errDryRun {
+			log.Println("dry run succeeded")
+			return nil
+		}
+		return err
+	}
+	if !empty {
+		if err := backup(version); err != nil {
+			return err
+		}
+	}
+	for _, m := range pending {
+		log.Println("migrating to version", m.version, ":", m.name)
+		err = DB.Update(func(tx *bolt.Tx) error {
+			if err := m.migrate(tx); err != nil {
+				return err
+			}
+			return setSchemaVersion(tx, m.version)
+		})
+		if err != nil {
+			return fmt.Errorf("migration to version %d failed: %s", m.version, err)
+		}
+	}
+	return nil
+}
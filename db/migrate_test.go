@@ -0,0 +1,154 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package db
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/shingetsu-gou/shingetsu-gou/cfg"
+)
+
+//has returns true if the db has key in bucket "test".
+func has(t *testing.T, key string) bool {
+	exist := false
+	err := DB.View(func(tx *bolt.Tx) error {
+		if b := tx.Bucket([]byte("test")); b != nil {
+			exist = b.Get([]byte(key)) != nil
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return exist
+}
+
+//version returns the schema version of the db.
+func version(t *testing.T) int {
+	var v int
+	err := DB.View(func(tx *bolt.Tx) error {
+		v = SchemaVersion(tx)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestMigrate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cfg.RunDir = dir
+	Open()
+	defer DB.Close()
+	saved := migrations
+	defer func() {
+		migrations = saved
+	}()
+	migrations = nil
+
+	err = DB.Update(func(tx *bolt.Tx) error {
+		return Put(tx, "test", []byte("seed"), 1)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var order []int
+	step := func(v int, fail bool) func(*bolt.Tx) error {
+		return func(tx *bolt.Tx) error {
+			order = append(order, v)
+			if err := Put(tx, "test", []byte(fmt.Sprint("v", v)), v); err != nil {
+				return err
+			}
+			if fail {
+				return errors.New("failed")
+			}
+			return nil
+		}
+	}
+	for _, v := range []int{3, 1, 2} {
+		RegistMigration(v, fmt.Sprint("step", v), step(v, false))
+	}
+	if LatestVersion() != 3 {
+		t.Fatal("illegal latest version", LatestVersion())
+	}
+
+	if err = Migrate(true); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(order, []int{1, 2, 3}) {
+		t.Error("illegal order", order)
+	}
+	if version(t) != 0 || has(t, "v1") || has(t, "v3") {
+		t.Error("db is changed by dry run")
+	}
+	if _, err = os.Stat(path.Join(dir, "gou_bolt.db.v0.bak")); err == nil {
+		t.Error("backed up by dry run")
+	}
+
+	order = nil
+	RegistMigration(4, "step4", step(4, true))
+	if err = Migrate(false); err == nil {
+		t.Fatal("failed migration is not reported")
+	}
+	if !reflect.DeepEqual(order, []int{1, 2, 3, 4}) {
+		t.Error("illegal order", order)
+	}
+	if version(t) != 3 || !has(t, "v3") || has(t, "v4") {
+		t.Error("failed migration is not rollbacked", version(t))
+	}
+
+	bak, err := bolt.Open(path.Join(dir, "gou_bolt.db.v0.bak"), 0644, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer bak.Close()
+	err = bak.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte("test"))
+		if b == nil || b.Get([]byte("seed")) == nil || b.Get([]byte("v1")) != nil {
+			t.Error("backup is not the db before migration")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,531 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package fsck
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/shingetsu-gou/shingetsu-gou/db"
+	"github.com/shingetsu-gou/shingetsu-gou/record"
+	"github.com/shingetsu-gou/shingetsu-gou/thread"
+)
+
+//Problem represents an inconsistency found in db.
+type Problem struct {
+	Bucket string
+	Key    string
+	Desc   string
+	repair func(tx *bolt.Tx) error
+}
+
+//Problems is slice of Problem for sorting by bucket and key.
+type Problems []*Problem
+
+//Len returns length of problems.
+func (p Problems) Len() int {
+	return len(p)
+}
+
+//Swap swaps p[i] and p[j].
+func (p Problems) Swap(i, j int) {
+	p[i], p[j] = p[j], p[i]
+}
+
+//Less returns true if p[i] is before p[j] in order of bucket and key.
+func (p Problems) Less(i, j int) bool {
+	if p[i].Bucket != p[j].Bucket {
+		return p[i].Bucket < p[j].Bucket
+	}
+	return p[i].Key < p[j].Key
+}
+
+//Repairable returns true if the problem can be repaired.
+func (p *Problem) Repairable() bool {
+	return p.repair != nil
+}
+
+//Check checks consistency of db and returns problems found.
+//if repair, repairs them in one transaction, which is rolled back if any repair fails.
+func Check(repair bool) (Problems, error) {
+	var ps Problems
+	f := db.DB.View
+	if repair {
+		f = db.DB.Update
+	}
+	err := f(func(tx *bolt.Tx) error {
+		var err error
+		if ps, err = checkTX(tx); err != nil || !repair {
+			return err
+		}
+		for _, p := range ps {
+			if p.repair == nil {
+				continue
+			}
+			if err := p.repair(tx); err != nil {
+				return fmt.Errorf("%s %s: %s", p.Bucket, p.Key, err)
+			}
+		}
+		return nil
+	})
+	return ps, err
+}
+
+//checkTX returns all problems in db.
+//problems of blobs come before ones of records, because removing a record releases its blob,
+//and problems of the search index come after them, because removing a record updates the index.
+//records which cannot be decoded are removed first, because removing a record reads
+//other records in the thread.
+func checkTX(tx *bolt.Tx) (Problems, error) {
+	rs, broken, invalid := checkRecords(tx)
+	var ps Problems
+	for _, p := range []Problems{
+		broken,
+		checkBlobs(tx, rs.refs),
+		invalid,
+		checkThreads(tx, rs.datfiles),
+		checkOrphans(tx, "threadMeta", rs.datfiles),
+		checkOrphans(tx, "threadAccess", readKeys(tx, "thread")),
+		checkPendings(tx, rs.datfiles),
+		checkIndex(tx, rs.alive),
+		checkMirror(tx, "lookupT", "lookupA"),
+		checkMirror(tx, "usertag", "usertagTag"),
+		checkKeylib(tx),
+	} {
+		sort.Stable(p)
+		ps = append(ps, p...)
+	}
+	return ps, nil
+}
+
+//recordSet represents records found in db.
+type recordSet struct {
+	refs     map[string]int64    //# of references to each blob
+	datfiles map[string]struct{} //threads which have records
+	alive    map[string]struct{} //keys of records which are not deleted and have no problems
+}
+
+//checkRecords verifies IDs of all records, and returns records found, problems of records
+//which cannot be decoded and ones of records whose IDs are invalid.
+func checkRecords(tx *bolt.Tx) (*recordSet, Problems, Problems) {
+	var broken, invalid Problems
+	rs := &recordSet{
+		refs:     make(map[string]int64),
+		datfiles: make(map[string]struct{}),
+		alive:    make(map[string]struct{}),
+	}
+	b := tx.Bucket([]byte("record"))
+	if b == nil {
+		return rs, nil, nil
+	}
+	c := b.Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		d := &record.DB{}
+		if err := json.Unmarshal(v, d); err != nil || d.Head == nil {
+			desc := "no head"
+			if err != nil {
+				desc = err.Error()
+			}
+			broken = append(broken, &Problem{
+				Bucket: "record",
+				Key:    recordKey(k),
+				Desc:   desc,
+				repair: del("record", string(k)),
+			})
+			continue
+		}
+		rs.datfiles[d.Datfile] = struct{}{}
+		if d.Attach != "" {
+			rs.refs[d.Attach]++
+		}
+		if err := d.VerifyTX(tx); err != nil {
+			invalid = append(invalid, &Problem{
+				Bucket: "record",
+				Key:    d.Datfile + "/" + d.Idstr(),
+				Desc:   err.Error(),
+				repair: d.Del,
+			})
+			continue
+		}
+		if !d.Deleted {
+			rs.alive[string(k)] = struct{}{}
+		}
+	}
+	return rs, broken, invalid
+}
+
+//recordKey returns the key of the record bucket k in the form of thread/stamp_id.
+func recordKey(k []byte) string {
+	i := bytes.IndexByte(k, 0x00)
+	if i < 0 || len(k) < i+9 {
+		return strconv.Quote(string(k))
+	}
+	stamp := int64(binary.BigEndian.Uint64(k[i+1 : i+9]))
+	return fmt.Sprintf("%s/%d_%s", k[:i], stamp, bytes.TrimSuffix(k[i+9:], []byte{0x00}))
+}
+
+//checkOrphans returns entries in bucket whose keys are not threads in datfiles.
+func checkOrphans(tx *bolt.Tx, bucket string, datfiles map[string]struct{}) Problems {
+	var ps Problems
+	for datfile := range readRaw(tx, bucket) {
+		if _, exist := datfiles[datfile]; exist {
+			continue
+		}
+		ps = append(ps, &Problem{
+			Bucket: bucket,
+			Key:    datfile,
+			Desc:   "the thread is not found",
+			repair: del(bucket, datfile),
+		})
+	}
+	return ps
+}
+
+//checkPendings returns deletion notices in pendingRemoval which are expired,
+//or whose threads have no records.
+func checkPendings(tx *bolt.Tx, datfiles map[string]struct{}) Problems {
+	var ps Problems
+	now := time.Now().Unix()
+	for k, v := range readRaw(tx, "pendingRemoval") {
+		p := &Problem{
+			Bucket: "pendingRemoval",
+			Key:    recordKey([]byte(k)),
+			repair: del("pendingRemoval", k),
+		}
+		datfile := k
+		if i := strings.IndexByte(k, 0x00); i >= 0 {
+			datfile = k[:i]
+		}
+		if _, exist := datfiles[datfile]; !exist {
+			p.Desc = "the thread is not found"
+		} else if record.PendingExpired([]byte(v), now) {
+			p.Desc = "expired"
+		} else {
+			continue
+		}
+		ps = append(ps, p)
+	}
+	return ps
+}
+
+//checkIndex returns entries in wordIndex which refer to records not in alive,
+//and counts in wordDF which differ from ones in wordIndex without such entries.
+func checkIndex(tx *bolt.Tx, alive map[string]struct{}) Problems {
+	var ps Problems
+	df := make(map[string]int)
+	docs := make(map[string]struct{})
+	for k := range readRaw(tx, "wordIndex") {
+		i := strings.IndexByte(k, 0x00)
+		if i < 0 {
+			ps = append(ps, &Problem{
+				Bucket: "wordIndex",
+				Key:    strconv.Quote(k),
+				Desc:   "illegal key",
+				repair: del("wordIndex", k),
+			})
+			continue
+		}
+		word, rkey := k[:i], k[i+1:]
+		if _, exist := alive[rkey]; !exist {
+			ps = append(ps, &Problem{
+				Bucket: "wordIndex",
+				Key:    word + " " + recordKey([]byte(rkey)),
+				Desc:   "the record is not found",
+				repair: del("wordIndex", k),
+			})
+			continue
+		}
+		df[word]++
+		docs[rkey] = struct{}{}
+	}
+	if len(docs) > 0 {
+		//0x00 is the key for # of indexed records.
+		df["\x00"] = len(docs)
+	}
+	stored := make(map[string]int)
+	for k, v := range readRaw(tx, "wordDF") {
+		stored[k] = -1
+		if len(v) == 8 {
+			stored[k] = int(binary.BigEndian.Uint64([]byte(v)))
+		}
+	}
+	for word, n := range df {
+		if m, exist := stored[word]; exist && m == n {
+			continue
+		}
+		word, n := word, n
+		ps = append(ps, &Problem{
+			Bucket: "wordDF",
+			Key:    strconv.Quote(word),
+			Desc:   fmt.Sprintf("%d is recorded but %d records have the word", stored[word], n),
+			repair: func(tx *bolt.Tx) error {
+				return db.Put(tx, "wordDF", []byte(word), n)
+			},
+		})
+	}
+	for word := range stored {
+		if _, exist := df[word]; exist {
+			continue
+		}
+		ps = append(ps, &Problem{
+			Bucket: "wordDF",
+			Key:    strconv.Quote(word),
+			Desc:   "no records have the word",
+			repair: del("wordDF", word),
+		})
+	}
+	return ps
+}
+//readRaw returns all keys and values in the bucket.
+func readRaw(tx *bolt.Tx, bucket string) map[string]string {
+	r := make(map[string]string)
+	b := tx.Bucket([]byte(bucket))
+	if b == nil {
+		return r
+	}
+	c := b.Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		r[string(k)] = string(v)
+	}
+	return r
+}
+
+//readKeys returns all keys in the bucket.
+func readKeys(tx *bolt.Tx, bucket string) map[string]struct{} {
+	r := make(map[string]struct{})
+	for k := range readRaw(tx, bucket) {
+		r[k] = struct{}{}
+	}
+	return r
+}
+
+//del returns a function which deletes key in bucket.
+func del(bucket, key string) func(*bolt.Tx) error {
+	return func(tx *bolt.Tx) error {
+		return db.Del(tx, bucket, []byte(key))
+	}
+}
+
+//checkBlobs checks that # of references to each blob in blobRef is same as refs,
+//and that no blob is unreferred.
+func checkBlobs(tx *bolt.Tx, refs map[string]int64) Problems {
+	var ps Problems
+	stored := readRaw(tx, "blobRef")
+	blobs := readRaw(tx, "blob")
+	for hash, n := range refs {
+		var m int64
+		if v, exist := stored[hash]; exist && len(v) == 8 {
+			m = int64(binary.BigEndian.Uint64([]byte(v)))
+		}
+		if _, exist := blobs[hash]; !exist {
+			ps = append(ps, &Problem{
+				Bucket: "blob",
+				Key:    hash,
+				Desc:   "referred by records but not found",
+			})
+		}
+		if m == n {
+			continue
+		}
+		n := n
+		ps = append(ps, &Problem{
+			Bucket: "blobRef",
+			Key:    hash,
+			Desc:   fmt.Sprintf("%d references are recorded but %d records refer", m, n),
+			repair: func(tx *bolt.Tx) error {
+				return db.Put(tx, "blobRef", []byte(hash), n)
+			},
+		})
+	}
+	for _, bucket := range []string{"blobRef", "blob"} {
+		for hash := range readRaw(tx, bucket) {
+			if _, exist := refs[hash]; exist {
+				continue
+			}
+			ps = append(ps, &Problem{
+				Bucket: bucket,
+				Key:    hash,
+				Desc:   "not referred by any record",
+				repair: del(bucket, hash),
+			})
+		}
+	}
+	return ps
+}
+
+//checkThreads returns threads which have no records.
+func checkThreads(tx *bolt.Tx, datfiles map[string]struct{}) Problems {
+	var ps Problems
+	for datfile := range readRaw(tx, "thread") {
+		if _, exist := datfiles[datfile]; exist {
+			continue
+		}
+		c := thread.NewCache(datfile)
+		ps = append(ps, &Problem{
+			Bucket: "thread",
+			Key:    datfile,
+			Desc:   "no records",
+			repair: c.RemoveTX,
+		})
+	}
+	return ps
+}
+
+//readMaps returns all values as map[string]struct{} in the bucket,
+//and problems of values which cannot be decoded.
+func readMaps(tx *bolt.Tx, bucket string) (map[string]map[string]struct{}, Problems) {
+	var ps Problems
+	r := make(map[string]map[string]struct{})
+	for k, v := range readRaw(tx, bucket) {
+		var m map[string]struct{}
+		if err := json.Unmarshal([]byte(v), &m); err != nil {
+			ps = append(ps, &Problem{
+				Bucket: bucket,
+				Key:    k,
+				Desc:   err.Error(),
+				repair: del(bucket, k),
+			})
+			continue
+		}
+		r[k] = m
+	}
+	return r, ps
+}
+
+//checkMirror checks that bucket b mirrors bucket a, i.e. b[v] has k iff a[k] has v.
+//a is authoritative, so entries missing in b are added, and ones only in b are removed.
+func checkMirror(tx *bolt.Tx, a, b string) Problems {
+	ma, ps := readMaps(tx, a)
+	mb, psb := readMaps(tx, b)
+	ps = append(ps, psb...)
+	for k, vs := range ma {
+		for v := range vs {
+			if _, exist := mb[v][k]; exist {
+				continue
+			}
+			k, v := k, v
+			ps = append(ps, &Problem{
+				Bucket: b,
+				Key:    v,
+				Desc:   fmt.Sprintf("%s is in %s but missing", k, a),
+				repair: func(tx *bolt.Tx) error {
+					return db.PutMap(tx, b, []byte(v), k)
+				},
+			})
+		}
+	}
+	for k, vs := range mb {
+		for v := range vs {
+			if _, exist := ma[v][k]; exist {
+				continue
+			}
+			k, v := k, v
+			ps = append(ps, &Problem{
+				Bucket: b,
+				Key:    k,
+				Desc:   fmt.Sprintf("%s is not in %s", v, a),
+				repair: func(tx *bolt.Tx) error {
+					return db.DelMap(tx, b, []byte(k), v)
+				},
+			})
+		}
+	}
+	return ps
+}
+
+//stampString returns the stamp in 8 bytes big endian s as a string.
+func stampString(s string) string {
+	if len(s) != 8 {
+		return strconv.Quote(s)
+	}
+	return strconv.FormatInt(int64(binary.BigEndian.Uint64([]byte(s))), 10)
+}
+
+//checkKeylib checks that keylibST mirrors keylibTS.
+//an entry missing in one side is restored from the other side,
+//and entries which conflict with the other side are removed.
+func checkKeylib(tx *bolt.Tx) Problems {
+	var ps Problems
+	ts := readRaw(tx, "keylibTS")
+	st := readRaw(tx, "keylibST")
+	for t, s := range ts {
+		t, s := t, s
+		p := &Problem{
+			Bucket: "keylibTS",
+			Key:    t,
+		}
+		switch tt, exist := st[s]; {
+		case len(s) != 8:
+			p.Desc = "illegal stamp " + strconv.Quote(s)
+			p.repair = del("keylibTS", t)
+		case !exist:
+			p.Desc = fmt.Sprintf("stamp %s is missing in keylibST", stampString(s))
+			p.repair = func(tx *bolt.Tx) error {
+				return db.Put(tx, "keylibST", []byte(s), t)
+			}
+		case tt != t:
+			p.Desc = fmt.Sprintf("stamp %s is used by %s", stampString(s), tt)
+			p.repair = del("keylibTS", t)
+		default:
+			continue
+		}
+		ps = append(ps, p)
+	}
+	for s, t := range st {
+		s, t := s, t
+		p := &Problem{
+			Bucket: "keylibST",
+			Key:    stampString(s),
+		}
+		switch ss, exist := ts[t]; {
+		case len(s) != 8:
+			p.Desc = "illegal stamp"
+			p.repair = del("keylibST", s)
+		case !exist:
+			p.Desc = fmt.Sprintf("%s is missing in keylibTS", t)
+			p.repair = func(tx *bolt.Tx) error {
+				return db.Put(tx, "keylibTS", []byte(t), []byte(s))
+			}
+		case ss != s:
+			p.Desc = fmt.Sprintf("%s has stamp %s", t, stampString(ss))
+			p.repair = del("keylibST", s)
+		default:
+			continue
+		}
+		ps = append(ps, p)
+	}
+	return ps
+}
@@ -0,0 +1,214 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package fsck
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boltdb/bolt"
+	"github.com/shingetsu-gou/shingetsu-gou/cfg"
+	"github.com/shingetsu-gou/shingetsu-gou/db"
+	"github.com/shingetsu-gou/shingetsu-gou/record"
+)
+
+func TestCheckMirror(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fsck")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	d, err := bolt.Open(filepath.Join(dir, "test.db"), 0644, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	check := func(tx *bolt.Tx) Problems {
+		return append(checkMirror(tx, "usertag", "usertagTag"), checkKeylib(tx)...)
+	}
+	err = d.Update(func(tx *bolt.Tx) error {
+		for _, kv := range [][3]string{
+			{"usertag", "thread_a", "tag1"},
+			{"usertag", "thread_a", "tag2"},
+			{"usertagTag", "tag1", "thread_a"},
+			{"usertagTag", "tag1", "thread_b"},
+		} {
+			if errr := db.PutMap(tx, kv[0], []byte(kv[1]), kv[2]); errr != nil {
+				return errr
+			}
+		}
+		for _, kv := range [][3]interface{}{
+			{"keylibTS", "thread_a", int64(1)},
+			{"keylibST", int64(1), "thread_a"},
+			{"keylibTS", "thread_b", int64(2)},
+			{"keylibST", int64(3), "thread_c"},
+			{"keylibST", int64(4), "thread_a"},
+		} {
+			if errr := db.Put(tx, kv[0].(string), db.MustTob(kv[1]), kv[2]); errr != nil {
+				return errr
+			}
+		}
+		ps := check(tx)
+		if len(ps) != 5 {
+			t.Error("illegal # of problems", len(ps))
+			for _, p := range ps {
+				t.Log(p.Bucket, p.Key, p.Desc)
+			}
+		}
+		for _, p := range ps {
+			if errr := p.repair(tx); errr != nil {
+				return errr
+			}
+		}
+		if ps = check(tx); len(ps) != 0 {
+			t.Error("problems are not repaired", ps[0].Bucket, ps[0].Key, ps[0].Desc)
+		}
+		if !db.HasVal(tx, "usertagTag", []byte("tag2"), "thread_a") || db.HasVal(tx, "usertagTag", []byte("tag1"), "thread_b") {
+			t.Error("usertagTag is not repaired")
+		}
+		var th string
+		if _, errr := db.Get(tx, "keylibST", db.MustTob(int64(2)), &th); errr != nil || th != "thread_b" {
+			t.Error("keylibST is not restored", th, errr)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fsck")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cfg.RunDir = dir
+	db.Setup()
+	defer db.DB.Close()
+	recs := make([]*record.Record, 3)
+	for i, body := range []map[string]string{
+		{"body": "foo bar"},
+		{"body": "baz", "attach": base64.StdEncoding.EncodeToString([]byte("file")), "suffix": "txt"},
+		{"body": "qux"},
+	} {
+		recs[i] = record.New("thread_a", "", 0)
+		recs[i].Build(int64(i+1), body, "")
+	}
+	err = db.DB.Update(func(tx *bolt.Tx) error {
+		if errr := db.Put(tx, "thread", []byte("thread_a"), []byte("")); errr != nil {
+			return errr
+		}
+		for _, r := range recs {
+			if errr := r.SyncTX(tx, false); errr != nil {
+				return errr
+			}
+		}
+		d, errr := record.GetFromDB(tx, recs[0].Head)
+		if errr != nil {
+			return errr
+		}
+		d.Body = "body:forged"
+		d2, errr := record.GetFromDB(tx, recs[1].Head)
+		if errr != nil {
+			return errr
+		}
+		for _, kv := range [][3]interface{}{
+			{"record", recs[0].Head.ToKey(), d},
+			{"record", db.ToKey("thread_a", int64(9), "bad"), []byte("{")},
+			{"blobRef", []byte(d2.Attach), int64(5)},
+			{"threadMeta", []byte("thread_x"), []byte("{}")},
+			{"threadAccess", []byte("thread_y"), int64(1)},
+			{"wordIndex", append(db.ToKey("foo"), db.ToKey("thread_z", int64(1), "id")...), []byte("{}")},
+			{"wordDF", []byte("zzz"), 3},
+			{"keylibTS", []byte("thread_b"), int64(2)},
+			{"keylibST", db.MustTob(int64(3)), "thread_c"},
+		} {
+			if errr := db.Put(tx, kv[0].(string), kv[1].([]byte), kv[2]); errr != nil {
+				return errr
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ps, err := Check(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buckets := make(map[string]int)
+	for _, p := range ps {
+		if !p.Repairable() {
+			t.Error("unrepairable problem", p.Bucket, p.Key, p.Desc)
+		}
+		buckets[p.Bucket]++
+	}
+	for _, b := range []string{"record", "blobRef", "threadMeta", "threadAccess", "wordIndex", "wordDF", "keylibTS", "keylibST"} {
+		if buckets[b] == 0 {
+			t.Error("no problems are found in", b)
+		}
+	}
+	if buckets["record"] != 2 {
+		t.Error("illegal # of problems of records", buckets["record"])
+	}
+	if k := recordKey(db.ToKey("thread_a", int64(9), "bad")); k != "thread_a/9_bad" {
+		t.Error("illegal record key", k)
+	}
+	if _, err = Check(true); err != nil {
+		t.Fatal(err)
+	}
+	if ps, err = Check(false); err != nil || len(ps) != 0 {
+		t.Fatal("problems are not repaired", ps, err)
+	}
+	err = db.DB.View(func(tx *bolt.Tx) error {
+		if has, _ := db.HasKey(tx, "record", recs[0].Head.ToKey()); has {
+			t.Error("forged record is not removed")
+		}
+		d, errr := record.GetFromDB(tx, recs[1].Head)
+		if errr != nil {
+			return errr
+		}
+		var n int64
+		if _, errr = db.Get(tx, "blobRef", []byte(d.Attach), &n); errr != nil || n != 1 {
+			t.Error("blobRef is not repaired", n, errr)
+		}
+		var th string
+		if _, errr = db.Get(tx, "keylibST", db.MustTob(int64(2)), &th); errr != nil || th != "thread_b" {
+			t.Error("keylibST is not restored", th, errr)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
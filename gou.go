@@ -31,6 +31,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
@@ -45,28 +46,50 @@ import (
 
 func main() {
 	fmt.Println("starting Gou", cfg.Version, "...")
-	var printLog, isSilent bool
+	var printLog, isSilent, dryRun bool
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "P2P anonymous BBS shinGETsu Gou %s\n", cfg.Version)
-		fmt.Fprintf(os.Stderr, "%s <options>\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "%s <options> [command]\n", os.Args[0])
+		fmt.Fprintln(os.Stderr, "commands:")
+		fmt.Fprint(os.Stderr, gou.CommandUsage())
+		fmt.Fprintln(os.Stderr, "options:")
 		flag.PrintDefaults()
 	}
 	flag.BoolVar(&printLog, "verbose", false, "print logs")
 	flag.BoolVar(&printLog, "v", false, "print logs")
 	flag.BoolVar(&isSilent, "silent", false, "suppress logs")
+	flag.BoolVar(&dryRun, "migrate-dry-run", false, "test migrations of db without saving and exit")
 	flag.Parse()
 	cfg.Parse()
 	gou.SetupDirectories()
 	gou.SetLogger(printLog, isSilent)
 	log.Println("********************starting Gou", cfg.Version, "...******************")
 	gou.ExpandAssets()
+	if dryRun {
+		db.Open()
+		if err := db.Migrate(true); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if !gou.IsServe(flag.Args()) {
+		if err := gou.RunCommand(flag.Args()); err != nil {
+			if err == gou.ErrUsage {
+				flag.Usage()
+			}
+			log.Fatal(err)
+		}
+		return
+	}
 	db.Setup()
-	listener, ch := gou.StartDaemon()
+	ctx, cancel := context.WithCancel(context.Background())
+	listener, ch := gou.StartDaemon(ctx)
 	c := make(chan os.Signal)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 	go func() {
 		for range c {
 			fmt.Println("exiting...")
+			cancel()
 			if err := listener.Close(); err != nil {
 				log.Println(err)
 			}
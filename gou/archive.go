@@ -0,0 +1,234 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package gou
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/boltdb/bolt"
+	"github.com/shingetsu-gou/shingetsu-gou/db"
+	"github.com/shingetsu-gou/shingetsu-gou/recentlist"
+	"github.com/shingetsu-gou/shingetsu-gou/record"
+	"github.com/shingetsu-gou/shingetsu-gou/tag/user"
+	"github.com/shingetsu-gou/shingetsu-gou/thread"
+	"github.com/shingetsu-gou/shingetsu-gou/util"
+)
+
+/*
+archive layout, which is same as cache dir of saku:
+
+<dir>/recent.txt                      stamp<>id<>thread per line
+<dir>/<thread>/record/<stamp>_<id>    stamp<>id<>body
+<dir>/<thread>/removed/<stamp>_<id>   stamp<>id<>body
+<dir>/<thread>/tag.txt                one user tag per line
+*/
+
+//writeLines writes lines to the file fname.
+func writeLines(fname string, lines []string) error {
+	if err := os.MkdirAll(filepath.Dir(fname), 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(fname)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	for _, l := range lines {
+		if _, err := w.WriteString(l + "\n"); err != nil {
+			util.Fclose(f)
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		util.Fclose(f)
+		return err
+	}
+	return f.Close()
+}
+
+//Export writes all records, user tags and recentlist to dir
+//in the format of saku cache.
+func Export(dir string) error {
+	cnt := 0
+	err := db.DB.View(func(tx *bolt.Tx) error {
+		return record.ForEach(tx, func(d *record.DB) error {
+			sub := "record"
+			if d.Deleted {
+				sub = "removed"
+			}
+			fname := filepath.Join(dir, d.Datfile, sub, d.Idstr())
+			body, err := d.InlineBodyTX(tx)
+			if err != nil {
+				return err
+			}
+			cnt++
+			return writeLines(fname, []string{fmt.Sprintf("%d<>%s<>%s", d.Stamp, d.ID, body)})
+		})
+	})
+	if err != nil {
+		return err
+	}
+	log.Println("exported", cnt, "records")
+	for _, ca := range thread.AllCaches() {
+		tags := user.GetStrings(ca.Datfile)
+		if len(tags) == 0 {
+			continue
+		}
+		if err := writeLines(filepath.Join(dir, ca.Datfile, "tag.txt"), tags); err != nil {
+			return err
+		}
+	}
+	recs := recentlist.GetRecords()
+	lines := make([]string, len(recs))
+	for i, r := range recs {
+		lines[i] = r.Recstr()
+	}
+	return writeLines(filepath.Join(dir, "recent.txt"), lines)
+}
+
+//importRecords reads records in dir and saves them to the thread datfile.
+//returns # of saved records.
+func importRecords(tx *bolt.Tx, datfile, dir string, removed bool) (int, error) {
+	fs, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	cnt := 0
+	for _, f := range fs {
+		if f.IsDir() {
+			continue
+		}
+		rec, err := record.NewIDstr(datfile, f.Name())
+		if err != nil {
+			continue
+		}
+		c, err := ioutil.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return cnt, err
+		}
+		if err := rec.Parse(strings.SplitN(string(c), "\n", 2)[0]); err != nil {
+			continue
+		}
+		if !rec.Meets(-1, -1) {
+			log.Println(datfile, f.Name(), "is broken, ignored")
+			continue
+		}
+		if err := rec.SyncTX(tx, removed || rec.IsSpam()); err != nil {
+			return cnt, err
+		}
+		cnt++
+	}
+	return cnt, nil
+}
+
+//importThread reads records and tags of the thread datfile in dir and saves them.
+func importThread(datfile, dir string) (int, error) {
+	var cnt int
+	err := db.DB.Update(func(tx *bolt.Tx) error {
+		for _, sub := range []string{"record", "removed"} {
+			n, err := importRecords(tx, datfile, filepath.Join(dir, sub), sub == "removed")
+			cnt += n
+			if err != nil {
+				return err
+			}
+		}
+		if cnt == 0 {
+			return nil
+		}
+		thread.NewCache(datfile).SubscribeTX(tx)
+		var tags []string
+		err := util.EachLine(filepath.Join(dir, "tag.txt"), func(line string, i int) error {
+			if line = strings.TrimSpace(line); line != "" {
+				tags = append(tags, line)
+			}
+			return nil
+		})
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return user.AddTX(tx, datfile, tags)
+	})
+	return cnt, err
+}
+
+//Import reads records, user tags and recentlist in dir
+//which is made by Export or is the cache dir of saku, and saves them.
+//records whose md5 are wrong are ignored.
+func Import(dir string) error {
+	if util.IsDir(filepath.Join(dir, "cache")) {
+		dir = filepath.Join(dir, "cache")
+	}
+	fs, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	total := 0
+	for _, f := range fs {
+		if !f.IsDir() || !strings.HasPrefix(f.Name(), "thread_") || util.FileDecode(f.Name()) == "" {
+			continue
+		}
+		cnt, err := importThread(f.Name(), filepath.Join(dir, f.Name()))
+		if err != nil {
+			return err
+		}
+		log.Println("imported", cnt, "records in", f.Name())
+		total += cnt
+	}
+	log.Println("imported", total, "records")
+	var recs []*record.Head
+	err = util.EachLine(filepath.Join(dir, "recent.txt"), func(line string, i int) error {
+		if rec, errr := record.Make(line); errr == nil {
+			recs = append(recs, rec.Head)
+		}
+		return nil
+	})
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return db.DB.Update(func(tx *bolt.Tx) error {
+		for _, r := range recs {
+			if err := recentlist.AppendTX(tx, r); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
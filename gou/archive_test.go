@@ -0,0 +1,140 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package gou
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/boltdb/bolt"
+	"github.com/shingetsu-gou/shingetsu-gou/cfg"
+	"github.com/shingetsu-gou/shingetsu-gou/db"
+	"github.com/shingetsu-gou/shingetsu-gou/record"
+	"github.com/shingetsu-gou/shingetsu-gou/tag/user"
+	"github.com/shingetsu-gou/shingetsu-gou/thread"
+	"github.com/shingetsu-gou/shingetsu-gou/util"
+)
+
+//dump returns all records in the db in wire format with the removed flag.
+func dump(t *testing.T) map[string]bool {
+	recs := make(map[string]bool)
+	err := db.DB.View(func(tx *bolt.Tx) error {
+		return record.ForEach(tx, func(d *record.DB) error {
+			body, err := d.InlineBodyTX(tx)
+			if err != nil {
+				return err
+			}
+			recs[fmt.Sprintf("%s/%d<>%s<>%s", d.Datfile, d.Stamp, d.ID, body)] = d.Deleted
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return recs
+}
+
+func TestArchive(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gou")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cfg.SpamList = "/nonexistent"
+	cfg.RunDir = filepath.Join(dir, "src")
+	if err = os.MkdirAll(cfg.RunDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	db.Setup()
+	datfile := util.FileEncode("thread", "archive")
+	attach := base64.StdEncoding.EncodeToString([]byte("attached file"))
+	err = db.DB.Update(func(tx *bolt.Tx) error {
+		for i, c := range []struct {
+			body    map[string]string
+			removed bool
+		}{
+			{map[string]string{"body": "foo", "name": "bar"}, false},
+			{map[string]string{"body": "with a file", "attach": attach, "suffix": "txt"}, false},
+			{map[string]string{"body": "removed"}, true},
+		} {
+			r := record.New(datfile, "", 0)
+			r.Build(int64(i+1), c.body, "pass")
+			if errr := r.SyncTX(tx, c.removed); errr != nil {
+				return errr
+			}
+		}
+		thread.NewCache(datfile).SubscribeTX(tx)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	user.Set(datfile, []string{"tag1", "tag2"})
+	exported := dump(t)
+	if len(exported) != 3 {
+		t.Fatal("illegal # of records", len(exported))
+	}
+	archive := filepath.Join(dir, "archive")
+	if err = Export(archive); err != nil {
+		t.Fatal(err)
+	}
+	if err = db.DB.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg.RunDir = filepath.Join(dir, "dst")
+	if err = os.MkdirAll(cfg.RunDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	db.Setup()
+	defer db.DB.Close()
+	if err = Import(archive); err != nil {
+		t.Fatal(err)
+	}
+	if imported := dump(t); !reflect.DeepEqual(exported, imported) {
+		t.Error("imported records differ", exported, imported)
+	}
+	if tags := user.GetStrings(datfile); len(tags) != 2 || !user.Has(datfile, "tag1") || !user.Has(datfile, "tag2") {
+		t.Error("illegal tags", tags)
+	}
+	r := record.New(datfile, "", 0)
+	r.Build(2, map[string]string{"body": "with a file", "attach": attach, "suffix": "txt"}, "pass")
+	l := record.New(datfile, r.ID, r.Stamp)
+	if err = l.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if !l.HasAttach() || l.CheckSign() != record.SignOK {
+		t.Error("attached file is not imported")
+	}
+}
@@ -0,0 +1,372 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package gou
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/shingetsu-gou/shingetsu-gou/cfg"
+	"github.com/shingetsu-gou/shingetsu-gou/db"
+	"github.com/shingetsu-gou/shingetsu-gou/fsck"
+	"github.com/shingetsu-gou/shingetsu-gou/node"
+	"github.com/shingetsu-gou/shingetsu-gou/node/manager"
+	"github.com/shingetsu-gou/shingetsu-gou/recentlist"
+	"github.com/shingetsu-gou/shingetsu-gou/record"
+	"github.com/shingetsu-gou/shingetsu-gou/thread"
+	"github.com/shingetsu-gou/shingetsu-gou/util"
+)
+
+//ErrUsage is returned when a command is unknown or its args are wrong.
+var ErrUsage = errors.New("illegal command")
+
+//command represents a subcommand.
+type command struct {
+	name  string
+	args  string
+	desc  string
+	nargs int
+	useDB bool
+	run   func(args []string) error
+}
+
+var commands = []*command{
+	{"serve", "", "start the daemon (default)", 0, true, nil},
+	{"status", "", "print status of the node", 0, true, printStatus},
+	{"db dump", "<bucket>", "print all keys and values in the bucket", 1, true, dumpBucket},
+	{"thread list", "", "print all threads", 0, true, listThreads},
+	{"thread rm", "<datfile|title>", "remove the thread", 1, true, removeThread},
+	{"record rm", "<datfile|title> <stamp_id>", "mark the record as removed", 2, true, removeRecord},
+	{"node list", "", "print nodes in the node list and the lookup table", 0, true, listNodes},
+	{"node ping", "[nodestr]", "ping the node, or all nodes in the node list", -1, true, pingNodes},
+	{"recent fetch", "", "get recent lists from nodes", 0, true, fetchRecent},
+	{"config check", "", "check saku.ini and files specified in it", 0, false, checkConfig},
+	{"fsck", "[repair]", "check consistency of db, and repair problems if repair is specified", -1, true, checkDB},
+	{"export", "<dir>", "export all records to dir in saku cache format", 1, true, func(args []string) error {
+		return Export(args[0])
+	}},
+	{"import", "<dir>", "import records in dir which is exported or saku cache", 1, true, func(args []string) error {
+		return Import(args[0])
+	}},
+}
+
+//CommandUsage returns usage of all subcommands.
+func CommandUsage() string {
+	var b bytes.Buffer
+	w := tabwriter.NewWriter(&b, 0, 8, 2, ' ', 0)
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %s %s\t%s\n", c.name, c.args, c.desc)
+	}
+	if err := w.Flush(); err != nil {
+		return ""
+	}
+	return b.String()
+}
+
+//findCommand returns the command and its args in args.
+func findCommand(args []string) (*command, []string) {
+	if len(args) == 0 {
+		return commands[0], nil
+	}
+	for _, c := range commands {
+		n := len(strings.Fields(c.name))
+		if len(args) < n || strings.Join(args[:n], " ") != c.name {
+			continue
+		}
+		cargs := args[n:]
+		if c.nargs >= 0 && len(cargs) != c.nargs {
+			return nil, nil
+		}
+		return c, cargs
+	}
+	return nil, nil
+}
+
+//IsServe returns true if args means to start the daemon.
+func IsServe(args []string) bool {
+	c, _ := findCommand(args)
+	return c == commands[0]
+}
+
+//RunCommand runs the subcommand in args, opening the db if needed.
+func RunCommand(args []string) error {
+	c, cargs := findCommand(args)
+	if c == nil || c.run == nil {
+		return ErrUsage
+	}
+	if c.useDB {
+		db.Setup()
+		defer func() {
+			if err := db.DB.Close(); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+		}()
+	}
+	return c.run(cargs)
+}
+
+//toDatfile returns datfile from datfile or title of the thread.
+func toDatfile(s string) string {
+	if strings.HasPrefix(s, "thread_") && util.FileDecode(s) != "" {
+		return s
+	}
+	return util.FileEncode("thread", s)
+}
+
+//printStatus prints # of threads, records, nodes and db info.
+func printStatus(args []string) error {
+	records, removed := 0, 0
+	var size int64
+	cas := thread.AllCaches()
+	for _, ca := range cas {
+		records += ca.Len(record.Alive)
+		removed += ca.Len(record.Removed)
+		size += ca.Size()
+	}
+	var version int
+	err := db.DB.View(func(tx *bolt.Tx) error {
+		version = db.SchemaVersion(tx)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(w, "version\t%s\n", cfg.Version)
+	fmt.Fprintf(w, "db schema\t%d/%d\n", version, db.LatestVersion())
+	fmt.Fprintf(w, "threads\t%d\n", len(cas))
+	fmt.Fprintf(w, "records\t%d\n", records)
+	fmt.Fprintf(w, "removed records\t%d\n", removed)
+	fmt.Fprintf(w, "cache size\t%.1fMB\n", float64(size)/(1024*1024))
+	fmt.Fprintf(w, "nodes in list\t%d\n", manager.ListLen())
+	fmt.Fprintf(w, "known nodes\t%d\n", manager.NodeLen())
+	fmt.Fprintf(w, "recent records\t%d\n", len(recentlist.GetRecords()))
+	return w.Flush()
+}
+
+//dumpBucket prints all keys and values in the bucket.
+func dumpBucket(args []string) error {
+	return db.DB.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(args[0]))
+		if b == nil {
+			return errors.New("bucket not found " + args[0])
+		}
+		return b.ForEach(func(k, v []byte) error {
+			_, err := fmt.Printf("%q\t%q\n", k, v)
+			return err
+		})
+	})
+}
+
+//listThreads prints all threads with # of records, size and last stamp.
+func listThreads(args []string) error {
+	cas := thread.AllCaches()
+	sort.Sort(sort.Reverse(thread.NewSortByStamp(cas, false)))
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "datfile\ttitle\trecords\tremoved\tsize\tstamp")
+	for _, ca := range cas {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%s\n", ca.Datfile, util.FileDecode(ca.Datfile),
+			ca.Len(record.Alive), ca.Len(record.Removed), ca.Size(),
+			time.Unix(ca.Stamp(), 0).Format("2006-01-02 15:04"))
+	}
+	return w.Flush()
+}
+
+//removeThread removes the thread and its records.
+func removeThread(args []string) error {
+	ca := thread.NewCache(toDatfile(args[0]))
+	if !ca.Exists() {
+		return errors.New("thread not found " + args[0])
+	}
+	ca.Remove()
+	fmt.Println("removed", ca.Datfile)
+	return nil
+}
+
+//removeRecord marks the record as removed.
+func removeRecord(args []string) error {
+	rec, err := record.NewIDstr(toDatfile(args[0]), args[1])
+	if err != nil {
+		return err
+	}
+	if !rec.Exists() {
+		return errors.New("record not found " + args[1])
+	}
+	if err := rec.Remove(); err != nil {
+		return err
+	}
+	fmt.Println("removed", rec.Datfile, rec.Idstr())
+	return nil
+}
+
+//listNodes prints nodes in the node list and all nodes in the lookup table.
+func listNodes(args []string) error {
+	fmt.Println("# node list")
+	for _, n := range manager.GetNodestrSliceInList() {
+		fmt.Println(n)
+	}
+	fmt.Println("# known nodes")
+	for _, n := range manager.GetNodestrSlice() {
+		fmt.Println(n)
+	}
+	return nil
+}
+
+//pingNodes pings the node in args or all nodes in the node list.
+func pingNodes(args []string) error {
+	nodestrs := args
+	if len(nodestrs) == 0 {
+		nodestrs = manager.GetNodestrSliceInList()
+	}
+	failed := 0
+	for _, ns := range nodestrs {
+		n, err := node.New(ns)
+		if err != nil {
+			return err
+		}
+		if ip, err := n.Ping(); err != nil {
+			fmt.Println(n.Nodestr, "NG", err)
+			failed++
+		} else {
+			fmt.Println(n.Nodestr, "OK", ip)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d nodes are not reachable", failed)
+	}
+	return nil
+}
+
+//fetchRecent gets all recent lists from nodes in the lookup table.
+func fetchRecent(args []string) error {
+	if manager.NodeLen() == 0 {
+		return errors.New("no nodes. start the daemon to gather nodes")
+	}
+	before := len(recentlist.GetRecords())
+	recentlist.Getall(context.Background(), true)
+	fmt.Println(len(recentlist.GetRecords())-before, "records were added to recent list")
+	return nil
+}
+
+//checkDB prints problems in db and repairs them if args[0] is "repair".
+func checkDB(args []string) error {
+	repair := len(args) == 1 && args[0] == "repair"
+	if len(args) > 0 && !repair {
+		return ErrUsage
+	}
+	ps, err := fsck.Check(repair)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	repaired := 0
+	for _, p := range ps {
+		s := "NG"
+		switch {
+		case repair && p.Repairable():
+			s = "REPAIRED"
+			repaired++
+		case p.Repairable():
+			s = "REPAIRABLE"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", s, p.Bucket, p.Key, p.Desc)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if repaired > 0 {
+		fmt.Println(repaired, "problems were repaired")
+	}
+	if n := len(ps) - repaired; n > 0 {
+		return fmt.Errorf("%d problems were found", n)
+	}
+	if len(ps) == 0 {
+		fmt.Println("no problems")
+	}
+	return nil
+}
+
+//checkConfig prints settings and checks files and regexps in them.
+func checkConfig(args []string) error {
+	ng := 0
+	check := func(name string, ok bool, val interface{}) {
+		s := "OK"
+		if !ok {
+			s = "NG"
+			ng++
+		}
+		fmt.Printf("%s\t%s\t%v\n", s, name, val)
+	}
+	check("port", cfg.DefaultPort > 0 && cfg.DefaultPort < 65536, cfg.DefaultPort)
+	check("docroot", util.IsDir(cfg.Docroot), cfg.Docroot)
+	check("run_dir", util.IsDir(cfg.RunDir), cfg.RunDir)
+	check("file_dir", util.IsDir(cfg.FileDir), cfg.FileDir)
+	check("log_dir", util.IsDir(cfg.LogDir), cfg.LogDir)
+	for name, f := range map[string]string{
+		"spam_list":      cfg.SpamList,
+		"initnode_list":  cfg.InitnodeList,
+		"node_allow":     cfg.NodeAllowFile,
+		"node_deny":      cfg.NodeDenyFile,
+		"moderator_list": cfg.ModeratorList,
+	} {
+		check(name, util.IsFile(f), f)
+	}
+	for name, re := range map[string]string{
+		"admin":   cfg.ReAdminStr,
+		"friend":  cfg.ReFriendStr,
+		"visitor": cfg.ReVisitorStr,
+	} {
+		_, err := regexp.Compile(re)
+		check(name, err == nil, re)
+	}
+	if cfg.ProxyHosts != "" {
+		_, err := regexp.Compile(cfg.ProxyHosts)
+		check("proxy_hosts", err == nil, cfg.ProxyHosts)
+	}
+	check("proxy", node.CheckProxy() == nil, cfg.Proxy)
+	inits := cfg.InitNode.GetData()
+	check("initial nodes", len(inits) > 0, len(inits))
+	for _, n := range inits {
+		_, err := node.New(n)
+		check("initial node", err == nil, n)
+	}
+	if ng > 0 {
+		return fmt.Errorf("%d settings are wrong", ng)
+	}
+	return nil
+}
@@ -29,6 +29,7 @@
 package gou
 
 import (
+	"context"
 	"log"
 	"time"
 
@@ -37,20 +38,24 @@ import (
 	"github.com/shingetsu-gou/shingetsu-gou/myself"
 	"github.com/shingetsu-gou/shingetsu-gou/node"
 	"github.com/shingetsu-gou/shingetsu-gou/node/manager"
+	"github.com/shingetsu-gou/shingetsu-gou/quota"
 	"github.com/shingetsu-gou/shingetsu-gou/recentlist"
 	"github.com/shingetsu-gou/shingetsu-gou/thread"
 	"github.com/shingetsu-gou/shingetsu-gou/thread/download"
+	"github.com/shingetsu-gou/shingetsu-gou/updateque"
 )
 
 var running bool
 
 //cron runs cron, and update everything if it is after specified cycle.
-func cron() {
+//cron stops when ctx is done.
+func cron(ctx context.Context) {
 	const (
 		shortCycle = 10 * time.Minute
 		longCycle  = time.Hour
 	)
 
+	go updateque.Run()
 	go func() {
 		getall := true
 		for {
@@ -58,31 +63,44 @@ func cron() {
 			myself.ResetPort()
 			ns := node.MustNew(cfg.InitNode.GetData())
 			if len(ns) == 0 {
-				log.Fatal("no init nodes")
+				log.Println("no init nodes")
 			}
 			for _, i := range ns {
 				if _, err := i.Ping(); err == nil {
 					manager.AppendToList(i)
 				}
 			}
-			nodes := ns[0].GetherNodes()
-			doSync(getall)
+			var nodes []*node.Node
+			if len(ns) > 0 {
+				nodes = ns[0].GetherNodes()
+			}
+			doSync(ctx, getall)
 
 			manager.Initialize(nodes)
-			doSync(getall)
+			doSync(ctx, getall)
 			keylib.Load()
 			log.Println("short cycle cron finished")
 			getall = false
-			<-time.After(shortCycle)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(shortCycle):
+			}
 		}
 	}()
 	go func() {
 		for {
-			<-time.After(longCycle)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(longCycle):
+			}
 			log.Println("long cycle cron started")
-			recentlist.Getall(true)
+			recentlist.Getall(ctx, true)
 			thread.CleanRecords()
 			thread.RemoveRemoved()
+			quota.Run()
+			log.Println(node.RemoveExpiredHealths(), "expired healths of nodes are removed")
 			log.Println("long cycle cron finished")
 		}
 	}()
@@ -92,12 +110,12 @@ func cron() {
 //doSync checks nodes in the nodelist are alive, reloads cachelist, removes old removed files,
 //reloads all tags from cachelist,reload srecent list from nodes in search list,
 //and reloads cache info from files in the disk.
-func doSync(fullRecent bool) {
+func doSync(ctx context.Context, fullRecent bool) {
 	if manager.ListLen() == 0 {
 		return
 	}
 	log.Println("recentList.getall start")
-	recentlist.Getall(fullRecent)
+	recentlist.Getall(ctx, fullRecent)
 	recentlist.RemoveOlds()
 	log.Println("recentList.getall finished")
 
@@ -107,7 +125,7 @@ func doSync(fullRecent bool) {
 		running = true
 		go func() {
 			log.Println("cacheList.getall start")
-			download.Getall()
+			download.Getall(ctx)
 			log.Println("cacheList.getall finished")
 			running = false
 			log.Println("heavymoon end")
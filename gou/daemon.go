@@ -30,6 +30,7 @@ package gou
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io/ioutil"
 	"log"
@@ -38,6 +39,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"runtime"
 	"strconv"
 	"time"
 
@@ -46,15 +48,20 @@ import (
 	"github.com/shingetsu-gou/shingetsu-gou/cfg"
 	"github.com/shingetsu-gou/shingetsu-gou/cgi"
 	"github.com/shingetsu-gou/shingetsu-gou/cgi/admin"
+	"github.com/shingetsu-gou/shingetsu-gou/cgi/api"
 	"github.com/shingetsu-gou/shingetsu-gou/cgi/gateway"
 	"github.com/shingetsu-gou/shingetsu-gou/cgi/mch"
 	"github.com/shingetsu-gou/shingetsu-gou/cgi/server"
 	"github.com/shingetsu-gou/shingetsu-gou/cgi/thread"
+	"github.com/shingetsu-gou/shingetsu-gou/lan"
+	"github.com/shingetsu-gou/shingetsu-gou/record"
+	"github.com/shingetsu-gou/shingetsu-gou/relay"
 	"github.com/shingetsu-gou/shingetsu-gou/util"
 )
 
 //StartDaemon setups saves pid, start cron job and a http server.
-func StartDaemon() (net.Listener, chan error) {
+//cron job is stopped when ctx is done.
+func StartDaemon(ctx context.Context) (net.Listener, chan error) {
 	p := os.Getpid()
 	err := ioutil.WriteFile(cfg.PID(), []byte(strconv.Itoa(p)), 0666)
 	if err != nil {
@@ -76,12 +83,15 @@ func StartDaemon() (net.Listener, chan error) {
 		MaxHeaderBytes: 1 << 20,
 	}
 
-	go cron()
+	go cron(ctx)
+	record.RunThumbnailer(runtime.NumCPU())
 
 	admin.Setup(sm)
 	server.Setup(sm)
 	gateway.Setup(sm)
 	thread.Setup(sm)
+	api.Setup(sm)
+	relay.Setup(sm)
 
 	if cfg.Enable2ch {
 		fmt.Println("started 2ch interface...")
@@ -91,6 +101,12 @@ func StartDaemon() (net.Listener, chan error) {
 		sm.RegisterPprof()
 	}
 	sm.RegistCompressHandler("/", handleRoot())
+	if cfg.NetworkMode == cfg.Relay {
+		go relay.Run(sm)
+	}
+	if cfg.LANDiscovery {
+		go lan.Run()
+	}
 	fmt.Println("started daemon and http server...")
 	ch := make(chan error)
 	go func() {
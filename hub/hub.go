@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package hub
+
+import "sync"
+
+//All is the topic to which all messages are published.
+const All = ""
+
+var (
+	mutex sync.RWMutex
+	subs  = make(map[string]map[chan interface{}]struct{})
+)
+
+//Subscribe returns a channel which receives messages published to topic.
+//size is the buffer size of the channel.
+func Subscribe(topic string, size int) chan interface{} {
+	mutex.Lock()
+	defer mutex.Unlock()
+	ch := make(chan interface{}, size)
+	if subs[topic] == nil {
+		subs[topic] = make(map[chan interface{}]struct{})
+	}
+	subs[topic][ch] = struct{}{}
+	return ch
+}
+
+//Unsubscribe removes ch from subscribers of topic and closes it.
+func Unsubscribe(topic string, ch chan interface{}) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	if _, exist := subs[topic][ch]; !exist {
+		return
+	}
+	delete(subs[topic], ch)
+	if len(subs[topic]) == 0 {
+		delete(subs, topic)
+	}
+	close(ch)
+}
+
+//Publish sends v to subscribers of topic and of All.
+//v is dropped for subscribers whose channel is full, so that
+//publishers never block.
+func Publish(topic string, v interface{}) {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	send := func(t string) {
+		for ch := range subs[t] {
+			select {
+			case ch <- v:
+			default:
+			}
+		}
+	}
+	send(topic)
+	if topic != All {
+		send(All)
+	}
+}
+
+//Len returns # of subscribers of topic.
+func Len(topic string) int {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return len(subs[topic])
+}
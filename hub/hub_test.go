@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package hub
+
+import "testing"
+
+func TestPublish(t *testing.T) {
+	a := Subscribe("a", 1)
+	all := Subscribe(All, 2)
+	Publish("a", 1)
+	Publish("b", 2)
+	Publish("a", 3) //dropped for a
+	if v := <-a; v != 1 {
+		t.Fatal("illegal message", v)
+	}
+	if len(a) != 0 {
+		t.Fatal("message must be dropped")
+	}
+	if v1, v2 := <-all, <-all; v1 != 1 || v2 != 2 {
+		t.Fatal("illegal messages", v1, v2)
+	}
+	Unsubscribe("a", a)
+	Unsubscribe("a", a)
+	if _, ok := <-a; ok {
+		t.Fatal("channel must be closed")
+	}
+	if Len("a") != 0 || Len(All) != 1 {
+		t.Fatal("illegal # of subscribers", Len("a"), Len(All))
+	}
+	Unsubscribe(All, all)
+}
@@ -0,0 +1,178 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package lan
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shingetsu-gou/shingetsu-gou/cfg"
+	"github.com/shingetsu-gou/shingetsu-gou/node"
+	"github.com/shingetsu-gou/shingetsu-gou/node/manager"
+)
+
+const (
+	//header is the first word of announcements.
+	header = "SHINGETSU-GOU/1"
+	//announceInterval is the interval of announcing mynode.
+	announceInterval = time.Minute
+	//pingInterval is the interval of pinging the same announced node.
+	pingInterval = 10 * time.Minute
+)
+
+var (
+	seen  = make(map[string]time.Time)
+	mutex sync.Mutex
+)
+
+//newID returns a random hex string to distinguish announcements from mynode.
+func newID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		log.Fatal(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+//message returns the announcement of mynode,
+//"SHINGETSU-GOU/1 <id> <port> <path>".
+func message(id string) string {
+	return fmt.Sprintf("%s %s %d %s", header, id, cfg.DefaultPort, strings.Replace(cfg.ServerURL, "/", "+", -1))
+}
+
+//parse parses the announcement msg sent from ip and returns the id and the node.
+func parse(msg string, ip net.IP) (string, *node.Node, error) {
+	f := strings.Fields(msg)
+	if len(f) != 4 || f[0] != header {
+		return "", nil, errors.New("illegal announcement " + msg)
+	}
+	port, err := strconv.Atoi(f[2])
+	if err != nil {
+		return "", nil, err
+	}
+	n, err := node.MakeNode(ip.String(), f[3], port)
+	return f[1], n, err
+}
+
+//Run announces mynode to the LAN by UDP multicast to LANGroup,
+//and adds nodes announced by others to the nodelist.
+func Run() {
+	group, err := net.ResolveUDPAddr("udp4", cfg.LANGroup)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	id := newID()
+	go announce(group, id)
+	listen(group, id)
+}
+
+//announce sends the announcement of mynode to group periodically.
+func announce(group *net.UDPAddr, id string) {
+	for {
+		conn, err := net.DialUDP("udp4", nil, group)
+		if err != nil {
+			log.Println(err)
+		} else {
+			if _, err := conn.Write([]byte(message(id))); err != nil {
+				log.Println(err)
+			}
+			if err := conn.Close(); err != nil {
+				log.Println(err)
+			}
+		}
+		time.Sleep(announceInterval)
+	}
+}
+
+//listen receives announcements in group and adds nodes which are not mynode.
+func listen(group *net.UDPAddr, id string) {
+	conn, err := net.ListenMulticastUDP("udp4", nil, group)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	defer conn.Close()
+	log.Println("listening announcements on", group)
+	buf := make([]byte, 512)
+	for {
+		l, addr, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			log.Println(err)
+			return
+		}
+		from, n, err := parse(string(buf[:l]), addr.IP)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		if from == id || !shouldPing(n, time.Now()) {
+			continue
+		}
+		go add(n)
+	}
+}
+
+//shouldPing returns true if n was not announced in pingInterval.
+func shouldPing(n *node.Node, now time.Time) bool {
+	mutex.Lock()
+	defer mutex.Unlock()
+	if t, exist := seen[n.Nodestr]; exist && now.Sub(t) < pingInterval {
+		return false
+	}
+	seen[n.Nodestr] = now
+	return true
+}
+
+//add adds n to the nodelist if n is allowed and alive, or forgets n to ping again,
+//and joins n so that n knows mynode without waiting for the announcement.
+func add(n *node.Node) {
+	if !n.IsAllowed() {
+		return
+	}
+	if _, err := n.Ping(); err != nil {
+		mutex.Lock()
+		delete(seen, n.Nodestr)
+		mutex.Unlock()
+		return
+	}
+	log.Println("found", n.Nodestr, "in LAN")
+	manager.AppendToList(n)
+	if _, err := n.Join(); err != nil {
+		log.Println(err)
+	}
+}
@@ -32,6 +32,7 @@ import (
 	"fmt"
 	"log"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/boltdb/bolt"
@@ -68,6 +69,9 @@ func Load() {
 		}
 		for _, rec := range allRecs {
 			c := thread.NewCache(rec.Datfile)
+			if c.EvictedTX(tx) {
+				continue
+			}
 			setFromCache(tx, c)
 		}
 		return nil
@@ -91,6 +95,18 @@ func setEntry(tx *bolt.Tx, stamp int64, filekey string) {
 	}
 }
 
+//RemoveTX removes the stamp/datfile pair of datfile.
+func RemoveTX(tx *bolt.Tx, datfile string) error {
+	stamp, err := getTime(tx, datfile)
+	if err != nil {
+		return nil
+	}
+	if err := db.Del(tx, "keylibST", db.MustTob(stamp)); err != nil {
+		return err
+	}
+	return db.Del(tx, "keylibTS", db.MustTob(datfile))
+}
+
 //setFromCache adds cache.datfile/timestamp pair if not exists.
 func setFromCache(tx *bolt.Tx, ca *thread.Cache) {
 	_, err := getTime(tx, ca.Datfile)
@@ -220,8 +236,16 @@ func MakeDat(ca *thread.Cache, board, host string) []string {
 		if name == "" {
 			name = "名無しさん"
 		}
-		if rec.GetBodyValue("pubkey", "") != "" {
-			name += "◆" + rec.GetBodyValue("pubkey", "")[:10]
+		name = strings.Replace(name, "◆", "◇", -1)
+		if pubkey := rec.GetBodyValue("pubkey", ""); pubkey != "" {
+			if len(pubkey) > 10 {
+				pubkey = pubkey[:10]
+			}
+			if rec.CheckSign() == record.SignOK {
+				name += "◆" + pubkey
+			} else {
+				name += "◇" + pubkey
+			}
 		}
 		comment := fmt.Sprintf("%s<>%s<>%s<>%s<>",
 			name, rec.GetBodyValue("main", ""), util.Datestr2ch(rec.Stamp), MakeBody(rec, host, board, table))
@@ -8,12 +8,14 @@ import (
 
 	nat "github.com/shingetsu-gou/go-nat"
 	"github.com/shingetsu-gou/shingetsu-gou/cfg"
+	"github.com/shingetsu-gou/shingetsu-gou/util"
 )
 
-var ip string
+var ip, ip6 string
 var externalPort *int32
 var mutex sync.RWMutex
 var status int
+var relayNode string
 
 //init returns Myself obj.
 func init() {
@@ -44,13 +46,30 @@ func SetStatus(stat int) {
 }
 
 //GetIPPort returns ip address and external port number.
+//IPv4 address is preferred if mynode has both.
 func GetIPPort() (string, int32) {
 	mutex.RLock()
 	defer mutex.RUnlock()
+	if ip == "" {
+		return ip6, *externalPort
+	}
 	return ip, *externalPort
 }
 
-//SetIP set my IP.
+//GetIPs returns all known global addresses of mynode.
+func GetIPs() []string {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	var ips []string
+	for _, i := range []string{ip, ip6} {
+		if i != "" {
+			ips = append(ips, i)
+		}
+	}
+	return ips
+}
+
+//SetIP set my IP. IPv4 and IPv6 addresses are kept separately for dual-stack nodes.
 func SetIP(ips string) {
 	mutex.Lock()
 	defer mutex.Unlock()
@@ -59,8 +78,34 @@ func SetIP(ips string) {
 		log.Println("ip", ips, "is illegal format")
 		return
 	}
-	if nat.IsGlobalIP(nip) != "" {
-		ip = ips
+	if !util.IsGlobalIP(nip) {
+		return
+	}
+	if nip.To4() != nil {
+		ip = nip.To4().String()
+	} else {
+		ip6 = nip.String()
+	}
+}
+
+//GetRelayNode returns nodestr of mynode via the relay server,
+//or "" if not relayed.
+func GetRelayNode() string {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return relayNode
+}
+
+//SetRelayNode sets nodestr of mynode via the relay server and sets status to Relay.
+//if nodestr is "", sets status to Disconnected.
+func SetRelayNode(nodestr string) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	relayNode = nodestr
+	if nodestr == "" {
+		status = cfg.Disconnected
+	} else {
+		status = cfg.Relay
 	}
 }
 
@@ -93,6 +138,8 @@ func connectionString() string {
 		return "normal"
 	case cfg.Disconnected:
 		return "disconnected"
+	case cfg.Relay:
+		return "relay"
 
 	}
 	return ""
@@ -100,7 +147,7 @@ func connectionString() string {
 
 //ResetPort setups connection.
 func ResetPort() {
-	if GetStatus() == cfg.Normal || GetStatus() == cfg.UPnP {
+	if GetStatus() == cfg.Normal || GetStatus() == cfg.UPnP || GetStatus() == cfg.Relay {
 		return
 	}
 	switch cfg.NetworkMode {
@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package node
+
+import (
+	"context"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/shingetsu-gou/shingetsu-gou/bandwidth"
+	"github.com/shingetsu-gou/shingetsu-gou/cfg"
+)
+
+//CapabilityHeader is the header of responses from server.cgi in which
+//extensions Gou supports are listed.
+const CapabilityHeader = "X-Gou-Capabilities"
+
+var (
+	client     *http.Client
+	clientOnce sync.Once
+)
+
+//PeerClient returns the http client shared for talking with nodes,
+//which is made from settings in saku.ini at the first call.
+//traffic of the client is counted by bandwidth package.
+func PeerClient() *http.Client {
+	clientOnce.Do(func() {
+		dialer := &net.Dialer{
+			Timeout:   time.Duration(cfg.DialTimeout) * time.Second,
+			KeepAlive: 30 * time.Second,
+		}
+		r, err := newRouter(dialer)
+		if err != nil {
+			log.Fatal("illegal proxy setting: ", err)
+		}
+		client = &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+					c, err := r.DialContext(ctx, network, addr)
+					if err != nil {
+						return nil, err
+					}
+					return bandwidth.NewConn(c), nil
+				},
+				MaxIdleConns:          cfg.MaxIdleConns,
+				MaxIdleConnsPerHost:   cfg.MaxConnsPerHost,
+				MaxConnsPerHost:       cfg.MaxConnsPerHost,
+				IdleConnTimeout:       time.Duration(cfg.IdleConnTimeout) * time.Second,
+				ResponseHeaderTimeout: time.Duration(cfg.ResponseTimeout) * time.Second,
+			},
+		}
+	})
+	return client
+}
+
+//idleReader is a reader which calls cancel if no data come for the timeout.
+type idleReader struct {
+	io.ReadCloser
+	timer   *time.Timer
+	timeout time.Duration
+}
+
+//newIdleReader returns idleReader which wraps r.
+func newIdleReader(r io.ReadCloser, timeout time.Duration, cancel context.CancelFunc) *idleReader {
+	return &idleReader{
+		ReadCloser: r,
+		timer:      time.AfterFunc(timeout, cancel),
+		timeout:    timeout,
+	}
+}
+
+//Read reads from the body and extends the deadline.
+func (r *idleReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.timer.Reset(r.timeout)
+	return n, err
+}
+
+//Close stops the timer and closes the body.
+func (r *idleReader) Close() error {
+	r.timer.Stop()
+	return r.ReadCloser.Close()
+}
@@ -0,0 +1,298 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package node
+
+import (
+	"encoding/json"
+	"log"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/shingetsu-gou/shingetsu-gou/db"
+)
+
+const (
+	healthHalfLife = 24 * time.Hour      // successes and failures are weighted half after this.
+	maxFails       = 5                   // node is regarded as dead after # of failures in a row.
+	latencyWeight  = 2000                // msec; weight is halved if latency is this.
+	healthExpire   = 30 * 24 * time.Hour // health of a dead node is removed if not seen for this.
+)
+
+//Health represents health of a node, which is updated after each talk with the node.
+type Health struct {
+	Nodestr        string
+	Successes      int     // # of successful talks.
+	Failures       int     // # of failed talks.
+	Good           float64 // # of successful talks decayed exponentially.
+	Bad            float64 // # of failed talks decayed exponentially.
+	Fails          int     // # of failed talks in a row.
+	ProtocolErrors int
+	Latency        int64 // moving average of latency in msec.
+	Bytes          int64
+	LastSeen       int64
+	Updated        int64
+	Capabilities   string // extensions of server.cgi which the node supports.
+}
+
+//decay returns the factor for decaying Good and Bad at now.
+func (h *Health) decay(now int64) float64 {
+	if h.Updated == 0 || now <= h.Updated {
+		return 1
+	}
+	return math.Pow(0.5, float64(now-h.Updated)/healthHalfLife.Seconds())
+}
+
+//Score returns the rate of successes in 0~1. Nodes without history get 0.5,
+//and scores of nodes not talked for a long time return to 0.5.
+func (h *Health) Score() float64 {
+	d := h.decay(time.Now().Unix())
+	return (h.Good*d + 1) / ((h.Good+h.Bad)*d + 2)
+}
+
+//Weight returns the weight for selecting the node, which is the score reduced by latency.
+func (h *Health) Weight() float64 {
+	return h.Score() / (1 + float64(h.Latency)/latencyWeight)
+}
+
+//IsDead returns true if the node failed maxFails times in a row.
+func (h *Health) IsDead() bool {
+	return h.Fails >= maxFails
+}
+
+//expired returns true if the node is dead and not seen for healthExpire at now.
+func (h *Health) expired(now int64) bool {
+	return h.IsDead() && now-h.LastSeen > int64(healthExpire.Seconds())
+}
+
+//Healths is slice of Health sorted by score.
+type Healths []*Health
+
+//Len returns size of healths.
+func (hs Healths) Len() int {
+	return len(hs)
+}
+
+//Swap swaps healths.
+func (hs Healths) Swap(i, j int) {
+	hs[i], hs[j] = hs[j], hs[i]
+}
+
+//Less returns true if the score of i is higher than that of j.
+func (hs Healths) Less(i, j int) bool {
+	return hs[i].Score() > hs[j].Score()
+}
+
+//Health returns the health of n.
+func (n *Node) Health() *Health {
+	h := &Health{Nodestr: n.Nodestr}
+	err := db.DB.View(func(tx *bolt.Tx) error {
+		_, err := db.Get(tx, "nodeHealth", []byte(n.Nodestr), h)
+		return err
+	})
+	if err != nil {
+		h = &Health{Nodestr: n.Nodestr}
+	}
+	return h
+}
+
+//AllHealths returns healths of all nodes which have talked with, sorted by score.
+func AllHealths() Healths {
+	var hs Healths
+	err := db.DB.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte("nodeHealth"))
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			h := &Health{}
+			if err := json.Unmarshal(v, h); err != nil {
+				log.Println(err)
+				return nil
+			}
+			hs = append(hs, h)
+			return nil
+		})
+	})
+	if err != nil {
+		log.Println(err)
+	}
+	sort.Sort(hs)
+	return hs
+}
+
+//RemoveExpiredHealths removes healths of nodes which are dead and not seen for a long time,
+//and returns # of removed ones.
+func RemoveExpiredHealths() int {
+	var n int
+	err := db.DB.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte("nodeHealth"))
+		if b == nil {
+			return nil
+		}
+		now := time.Now().Unix()
+		var olds [][]byte
+		err := b.ForEach(func(k, v []byte) error {
+			h := &Health{}
+			if err := json.Unmarshal(v, h); err != nil || h.expired(now) {
+				olds = append(olds, k)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, k := range olds {
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+		}
+		n = len(olds)
+		return nil
+	})
+	if err != nil {
+		log.Println(err)
+	}
+	return n
+}
+
+//updateHealth updates the health of n by fn.
+func (n *Node) updateHealth(fn func(h *Health, now int64)) {
+	err := db.DB.Batch(func(tx *bolt.Tx) error {
+		h := &Health{}
+		if _, err := db.Get(tx, "nodeHealth", []byte(n.Nodestr), h); err != nil {
+			h = &Health{}
+		}
+		now := time.Now().Unix()
+		d := h.decay(now)
+		h.Good *= d
+		h.Bad *= d
+		h.Nodestr = n.Nodestr
+		fn(h, now)
+		h.Updated = now
+		return db.Put(tx, "nodeHealth", []byte(n.Nodestr), h)
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+//talked records the result of a talk with n and capabilities n told.
+func (n *Node) talked(err error, latency time.Duration, bytes int, caps string) {
+	n.updateHealth(func(h *Health, now int64) {
+		h.Bytes += int64(bytes)
+		if err != nil {
+			h.Failures++
+			h.Bad++
+			h.Fails++
+			return
+		}
+		h.Successes++
+		h.Good++
+		h.Fails = 0
+		h.LastSeen = now
+		h.Capabilities = caps
+		ms := int64(latency / time.Millisecond)
+		if h.Latency == 0 {
+			h.Latency = ms
+		} else {
+			h.Latency = (h.Latency*3 + ms) / 4
+		}
+	})
+}
+
+//ProtocolError records that n returned an illegal response.
+func (n *Node) ProtocolError() {
+	n.updateHealth(func(h *Health, now int64) {
+		h.ProtocolErrors++
+		h.Bad++
+	})
+}
+
+//Capable returns true if n told that it supports the extension cap at the last talk.
+func (n *Node) Capable(cap string) bool {
+	for _, c := range strings.Fields(n.Health().Capabilities) {
+		if c == cap {
+			return true
+		}
+	}
+	return false
+}
+
+//IsDead returns true if n failed to talk many times in a row.
+func (n *Node) IsDead() bool {
+	return n.Health().IsDead()
+}
+
+//Weighted returns a shuffled copy of ns, where nodes with higher weight of health
+//tend to come first.
+func (ns Slice) Weighted() Slice {
+	ws := make(map[string]float64)
+	err := db.DB.View(func(tx *bolt.Tx) error {
+		for _, n := range ns {
+			h := &Health{}
+			if _, err := db.Get(tx, "nodeHealth", []byte(n.Nodestr), h); err != nil {
+				h = &Health{}
+			}
+			ws[n.Nodestr] = h.Weight()
+		}
+		return nil
+	})
+	if err != nil {
+		log.Println(err)
+	}
+	//weighted random sampling by Efraimidis and Spirakis.
+	keys := make(map[string]float64)
+	for _, n := range ns {
+		keys[n.Nodestr] = math.Pow(rand.Float64(), 1/ws[n.Nodestr])
+	}
+	r := make(Slice, len(ns))
+	copy(r, ns)
+	sort.Sort(&byKey{r, keys})
+	return r
+}
+
+//byKey is for sorting nodes by keys in descending order.
+type byKey struct {
+	Slice
+	keys map[string]float64
+}
+
+//Swap swaps nodes.
+func (b *byKey) Swap(i, j int) {
+	b.Slice[i], b.Slice[j] = b.Slice[j], b.Slice[i]
+}
+
+//Less returns true if the key of i is larger than that of j.
+func (b *byKey) Less(i, j int) bool {
+	return b.keys[b.Slice[i].Nodestr] > b.keys[b.Slice[j].Nodestr]
+}
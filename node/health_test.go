@@ -0,0 +1,156 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package node
+
+import (
+	"errors"
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/shingetsu-gou/shingetsu-gou/db"
+)
+
+//putHealths saves hs to the db as they are.
+func putHealths(t *testing.T, hs ...*Health) {
+	err := db.DB.Update(func(tx *bolt.Tx) error {
+		for _, h := range hs {
+			if err := db.Put(tx, "nodeHealth", []byte(h.Nodestr), h); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScore(t *testing.T) {
+	now := time.Now().Unix()
+	h := &Health{Good: 10, Updated: now - int64(healthHalfLife.Seconds())}
+	if d := h.decay(now); math.Abs(d-0.5) > 0.01 {
+		t.Error("illegal decay", d)
+	}
+	if s := (&Health{}).Score(); s != 0.5 {
+		t.Error("score without history should be 0.5", s)
+	}
+	h.Updated = now
+	if s := h.Score(); math.Abs(s-11.0/12) > 0.01 {
+		t.Error("illegal score", s)
+	}
+	h.Updated = now - 100*int64(healthHalfLife.Seconds())
+	if s := h.Score(); math.Abs(s-0.5) > 0.01 {
+		t.Error("score does not return to 0.5", s)
+	}
+	if fast, slow := (&Health{Latency: 10}).Weight(), (&Health{Latency: latencyWeight}).Weight(); slow >= fast {
+		t.Error("latency is not considered", fast, slow)
+	}
+}
+
+func TestDead(t *testing.T) {
+	dir := setupDB(t)
+	defer os.RemoveAll(dir)
+	defer db.DB.Close()
+	n, err := New("192.0.2.1:8000/server.cgi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < maxFails; i++ {
+		if n.IsDead() {
+			t.Fatal("dead after failures", i)
+		}
+		n.talked(errors.New("failed"), time.Second, 0, "")
+	}
+	if !n.IsDead() {
+		t.Fatal("not dead after failures in a row")
+	}
+	n.talked(nil, time.Second, 10, "bulk")
+	h := n.Health()
+	if n.IsDead() || h.Fails != 0 || h.Successes != 1 || h.Failures != maxFails || !n.Capable("bulk") {
+		t.Error("illegal health after success", h)
+	}
+}
+
+func TestRemoveExpiredHealths(t *testing.T) {
+	dir := setupDB(t)
+	defer os.RemoveAll(dir)
+	defer db.DB.Close()
+	old := time.Now().Add(-healthExpire).Unix() - 1
+	recent := time.Now().Unix()
+	putHealths(t,
+		&Health{Nodestr: "expired", Fails: maxFails, LastSeen: old},
+		&Health{Nodestr: "dead", Fails: maxFails, LastSeen: recent},
+		&Health{Nodestr: "alive", LastSeen: old},
+	)
+	if n := RemoveExpiredHealths(); n != 1 {
+		t.Error("illegal # of removed healths", n)
+	}
+	hs := AllHealths()
+	if len(hs) != 2 {
+		t.Fatal("illegal # of healths", len(hs))
+	}
+	for _, h := range hs {
+		if h.Nodestr == "expired" {
+			t.Error("expired health is not removed")
+		}
+	}
+}
+
+func TestWeighted(t *testing.T) {
+	dir := setupDB(t)
+	defer os.RemoveAll(dir)
+	defer db.DB.Close()
+	now := time.Now().Unix()
+	good := &Node{Nodestr: "192.0.2.1:8000/server.cgi"}
+	bad := &Node{Nodestr: "192.0.2.2:8000/server.cgi"}
+	putHealths(t,
+		&Health{Nodestr: good.Nodestr, Good: 100, Updated: now},
+		&Health{Nodestr: bad.Nodestr, Bad: 100, Updated: now},
+	)
+	ns := Slice{bad, good}
+	first := 0
+	for i := 0; i < 100; i++ {
+		r := ns.Weighted()
+		if len(r) != 2 || !r.Has(good) || !r.Has(bad) {
+			t.Fatal("illegal nodes", r)
+		}
+		if r[0] == good {
+			first++
+		}
+	}
+	if first < 80 {
+		t.Error("healthy node does not tend to come first", first)
+	}
+	if ns[0] != bad {
+		t.Error("original slice is changed")
+	}
+}
@@ -31,9 +31,6 @@ package manager
 import (
 	"errors"
 	"log"
-	"math/rand"
-	"strconv"
-	"strings"
 	"sync"
 
 	"github.com/boltdb/bolt"
@@ -53,7 +50,7 @@ var list = string([]byte{0x01})
 
 //Manager represents the map that maps datfile to it's source node list.
 
-//getFromList returns one node  in the nodelist.
+//getFromList returns one node in the nodelist, which is replaced by a new node.
 func getFromList() *node.Node {
 	var rs map[string]struct{}
 	err := db.DB.View(func(tx *bolt.Tx) error {
@@ -69,17 +66,21 @@ func getFromList() *node.Node {
 		log.Println("node not found")
 		return nil
 	}
-	var r string
-	for rr := range rs {
-		r = rr
-		break
+	var ns node.Slice
+	for r := range rs {
+		n, err := node.New(r)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		ns = append(ns, n)
 	}
-	n, err := node.New(r)
-	if err != nil {
-		log.Println(err)
+	if len(ns) == 0 {
 		return nil
 	}
-	return n
+	//returns the node which is likely to be the worst.
+	ns = ns.Weighted()
+	return ns[len(ns)-1]
 }
 
 //NodeLen returns size of all nodes.
@@ -158,6 +159,7 @@ func GetNodestrSliceInTable(datfile string) []string {
 }
 
 //Random selects # of min(all # of nodes,n) nodes randomly except exclude nodes.
+//Nodes with higher health scores are likely to be selected.
 func Random(exclude node.Slice, num int) []*node.Node {
 	all := getAllNodes()
 	if exclude != nil {
@@ -174,12 +176,7 @@ func Random(exclude node.Slice, num int) []*node.Node {
 	if num < n && num != 0 {
 		n = num
 	}
-	r := make([]*node.Node, n)
-	rs := rand.Perm(all.Len())
-	for i := 0; i < n; i++ {
-		r[i] = all[rs[i]]
-	}
-	return r
+	return all.Weighted()[:n]
 }
 
 func appendable(datfile string, n *node.Node) bool {
@@ -293,6 +290,23 @@ func RemoveFromTable(datfile string, n *node.Node) bool {
 	return true
 }
 
+//RemoveThreadTX removes thread datfile and all nodes which have it from the lookup table.
+func RemoveThreadTX(tx *bolt.Tx, datfile string) error {
+	if tx.Bucket([]byte("lookupT")) == nil {
+		return nil
+	}
+	nodes, err := db.GetMap(tx, "lookupT", []byte(datfile))
+	if err != nil {
+		return nil
+	}
+	for n := range nodes {
+		if err := db.DelMap(tx, "lookupA", []byte(n), datfile); err != nil {
+			log.Println(err)
+		}
+	}
+	return db.Del(tx, "lookupT", []byte(datfile))
+}
+
 //RemoveFromList removes node n from nodelist and return true if exists.
 //or returns false if not exists.
 func RemoveFromList(n *node.Node) bool {
@@ -357,7 +371,7 @@ func Initialize(allnodes node.Slice) {
 	wg.Wait()
 
 	log.Println("# of nodelist:", ListLen())
-	if port0 {
+	if port0 && myself.GetStatus() != cfg.Relay {
 		log.Println("port0")
 		myself.SetStatus(cfg.Port0)
 	} else {
@@ -373,7 +387,7 @@ func Join(n *node.Node) bool {
 	if n == nil {
 		return false
 	}
-	if hasNodeInTable(list, n) || node.Me(false).Nodestr == n.Nodestr {
+	if hasNodeInTable(list, n) || n.IsMe() {
 		return false
 	}
 	flag := false
@@ -393,38 +407,24 @@ func Join(n *node.Node) bool {
 	return flag
 }
 
-//TellUpdate makes mynode info from node or dnsname or ip addr,
-//and broadcast the updates of record id=id in cache c.datfile with stamp.
-func TellUpdate(datfile string, stamp int64, id string, n *node.Node) {
+//NodesForUpdate returns nodes to which updates of records in datfile are told.
+func NodesForUpdate(datfile string) node.Slice {
 	const updateNodes = 10
 
-	tellstr := node.Me(true).Toxstring()
-	if n != nil {
-		tellstr = n.Toxstring()
-	}
-	msg := strings.Join([]string{"/update", datfile, strconv.FormatInt(stamp, 10), id, tellstr}, "/")
-
 	ns := Get(datfile, nil)
 	ns = ns.Extend(Get(list, nil))
-	ns = ns.Extend(Random(ns, updateNodes))
-	log.Println("telling #", len(ns))
-	for _, n := range ns {
-		_, err := n.Talk(msg, nil)
-		if err != nil {
-			log.Println(err)
-		}
-	}
+	return ns.Extend(Random(ns, updateNodes))
 }
 
 //NodesForGet returns nodes which has datfile cache , and that extends nodes to #searchDepth .
 func NodesForGet(datfile string, searchDepth int) node.Slice {
 	var ns, ns2 node.Slice
-	ns = ns.Extend(Get(datfile, nil))
-	ns = ns.Extend(Get(list, nil))
+	ns = ns.Extend(Get(datfile, nil).Weighted())
+	ns = ns.Extend(Get(list, nil).Weighted())
 	ns = ns.Extend(Random(ns, 0))
 
 	for _, n := range ns {
-		if !n.Equals(node.Me(true)) && n.IsAllowed() {
+		if !n.IsMe() && n.IsAllowed() {
 			ns2 = append(ns2, n)
 		}
 	}
@@ -29,8 +29,10 @@
 package node
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net"
 	"net/http"
@@ -40,6 +42,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/shingetsu-gou/shingetsu-gou/bandwidth"
 	"github.com/shingetsu-gou/shingetsu-gou/cfg"
 	"github.com/shingetsu-gou/shingetsu-gou/myself"
 	"github.com/shingetsu-gou/shingetsu-gou/util"
@@ -71,52 +74,121 @@ func New(nodestr string) (*Node, error) {
 		log.Println(err)
 		return nil, err
 	}
+	nodestr = strings.Replace(nodestr, "+", "/", -1)
 	if match, err := regexp.MatchString(`\d+/[^: ]+$`, nodestr); !match || err != nil {
 		errr := errors.New(fmt.Sprintln("bad format", err, nodestr))
 		return nil, errr
 	}
+	i := strings.Index(nodestr, "/")
+	host, port, err := net.SplitHostPort(nodestr[:i])
+	if err != nil {
+		return nil, errors.New(fmt.Sprintln("bad format", err, nodestr))
+	}
+	if _, err = strconv.Atoi(port); err != nil {
+		return nil, errors.New(fmt.Sprintln("bad port", err, nodestr))
+	}
+	//normalize IPv6 address, e.g. [2001:0db8:0::1] to [2001:db8::1].
+	if ip := net.ParseIP(host); ip != nil {
+		host = ip.String()
+	}
 	n := &Node{
-		Nodestr: strings.Replace(nodestr, "+", "/", -1),
+		Nodestr: net.JoinHostPort(host, port) + nodestr[i:],
 	}
 	return n, nil
 }
 
-//urlopen retrievs html data from url
-func (n *Node) urlopen(url string, timeout time.Duration, fn func(string) error) error {
+//IP returns IP address of n, or nil if n is specified by hostname.
+func (n *Node) IP() net.IP {
+	i := strings.Index(n.Nodestr, "/")
+	host, _, err := net.SplitHostPort(n.Nodestr[:i])
+	if err != nil {
+		return nil
+	}
+	return net.ParseIP(host)
+}
+
+//ErrTooManyRequests is returned if the node refused the request because of too many requests,
+//or if the node told to retry later and the time has not come yet.
+var ErrTooManyRequests = errors.New("too many requests")
+
+//defaultRetryAfter is the time not to talk with a node which responded 429 without Retry-After.
+const defaultRetryAfter = time.Minute
+
+var (
+	retryAfter   = make(map[string]time.Time)
+	retryAfterMu sync.Mutex
+)
+
+//parseRetryAfter returns the duration told by Retry-After header v at now,
+//or defaultRetryAfter if v is illegal.
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	if sec, err := strconv.Atoi(v); err == nil && sec >= 0 {
+		return time.Duration(sec) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return defaultRetryAfter
+}
+
+//backoff stops talking with n for the duration told by Retry-After header v.
+func (n *Node) backoff(v string) {
+	now := time.Now()
+	retryAfterMu.Lock()
+	defer retryAfterMu.Unlock()
+	retryAfter[n.Nodestr] = now.Add(parseRetryAfter(v, now))
+}
+
+//throttled returns true if n told to retry later and the time has not come yet.
+func (n *Node) throttled() bool {
+	retryAfterMu.Lock()
+	defer retryAfterMu.Unlock()
+	t, exist := retryAfter[n.Nodestr]
+	if !exist {
+		return false
+	}
+	if time.Now().Before(t) {
+		return true
+	}
+	delete(retryAfter, n.Nodestr)
+	return false
+}
+
+//urlopen retrievs html data from url by method with body,
+//and returns capabilities in the response header.
+//the request fails if no data come for ReadTimeout while reading the body.
+func (n *Node) urlopen(ctx context.Context, method, url string, body io.Reader, fn func(string) error) (string, error) {
 	ua := "shinGETsuPlus/1.0alpha (Gou/" + cfg.Version + ")"
 
-	req, err := http.NewRequest("GET", url, nil)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	req, err := http.NewRequest(method, url, body)
 	if err != nil {
 		log.Println(err)
-		return err
+		return "", err
 	}
+	req = req.WithContext(ctx)
 	req.Header.Set("User-Agent", ua)
-
-	transport := http.Transport{
-		Dial: func(network, addr string) (net.Conn, error) {
-			con, errr := net.DialTimeout(network, addr, timeout)
-			if errr != nil {
-				return nil, errr
-			}
-			errr = con.SetDeadline(time.Now().Add(time.Minute))
-			return con, errr
-		},
+	if body != nil {
+		req.Header.Set("Content-Type", "text/plain")
 	}
 
-	client := http.Client{
-		Transport: &transport,
-		Timeout:   timeout,
-	}
-
-	resp, err := client.Do(req)
+	resp, err := PeerClient().Do(req)
 	if err != nil {
 		log.Println(err)
-		return err
+		return "", err
 	}
-	err = util.EachIOLine(resp.Body, func(line string, i int) error {
+	caps := resp.Header.Get(CapabilityHeader)
+	if resp.StatusCode == http.StatusTooManyRequests {
+		resp.Body.Close()
+		n.backoff(resp.Header.Get("Retry-After"))
+		return caps, ErrTooManyRequests
+	}
+	r := newIdleReader(resp.Body, time.Duration(cfg.ReadTimeout)*time.Second, cancel)
+	err = util.EachIOLine(r, func(line string, i int) error {
 		return fn(line)
 	})
-	return err
+	return caps, err
 }
 
 //Equals return true is Nodestr is equal.
@@ -140,7 +212,24 @@ func (n *Node) Toxstring() string {
 
 //Talk talks with n with the message and returns data.
 func (n *Node) Talk(message string, fn func(string) error) ([]string, error) {
-	const defaultTimeout = 15 * time.Second // Seconds; Timeout for TCP
+	return n.TalkContext(context.Background(), message, fn)
+}
+
+//TalkContext talks with n with the message and returns data.
+//Talking is canceled when ctx is done.
+func (n *Node) TalkContext(ctx context.Context, message string, fn func(string) error) ([]string, error) {
+	return n.talk(ctx, "GET", message, nil, fn)
+}
+
+//PostContext posts body to n with the message and returns data.
+//Talking is canceled when ctx is done.
+func (n *Node) PostContext(ctx context.Context, message string, body io.Reader, fn func(string) error) ([]string, error) {
+	return n.talk(ctx, "POST", message, body, fn)
+}
+
+//talk talks with n with the message by method and returns data.
+//if fn is not nil, fn is called for each line instead of returning data.
+func (n *Node) talk(ctx context.Context, method, message string, body io.Reader, fn func(string) error) ([]string, error) {
 	var res []string
 	if fn == nil {
 		fn = func(line string) error {
@@ -157,13 +246,39 @@ func (n *Node) Talk(message string, fn func(string) error) ([]string, error) {
 		log.Println(err)
 		return nil, err
 	}
+	if bandwidth.Heavy(strings.SplitN(message[1:], "/", 2)[0]) && bandwidth.State(bandwidth.Download) == bandwidth.Capped {
+		log.Println(message, bandwidth.ErrCapped)
+		return nil, bandwidth.ErrCapped
+	}
 	msg := "http://" + n.Nodestr + message
+	if n.throttled() {
+		log.Println(msg, ErrTooManyRequests)
+		return nil, ErrTooManyRequests
+	}
 
 	log.Println("Talk:", msg)
-	err := n.urlopen(msg, defaultTimeout, fn)
+	var bytes int
+	var errFn error
+	start := time.Now()
+	caps, err := n.urlopen(ctx, method, msg, body, func(line string) error {
+		bytes += len(line) + 1
+		errFn = fn(line)
+		return errFn
+	})
 	if err != nil {
 		log.Println(msg, err)
 	}
+	switch {
+	case ctx.Err() != nil:
+		//canceled by the caller, not failures of n.
+	case err == ErrTooManyRequests:
+		//n is alive but busy, talk later.
+	case err == errFn:
+		//errors from fn are not failures of connection.
+		n.talked(nil, time.Since(start), bytes, caps)
+	default:
+		n.talked(err, time.Since(start), bytes, caps)
+	}
 	return res, err
 }
 
@@ -180,6 +295,7 @@ func (n *Node) Ping() (string, error) {
 		return res[1], nil
 	}
 	log.Println("/ping", n.Nodestr, "error")
+	n.ProtocolError()
 	return "", errors.New("connected,but not ponged")
 }
 
@@ -200,13 +316,14 @@ func (n *Node) Join() (*Node, error) {
 		err := errors.New(fmt.Sprintln(n.Nodestr, "is not allowd"))
 		return nil, err
 	}
-	res, err := n.Talk("/join/"+Me(true).Toxstring(), nil)
+	res, err := n.Talk("/join/"+MeFor(n, true).Toxstring(), nil)
 	if err != nil {
 		return nil, err
 	}
 	log.Println(n.Nodestr, "response of Join:", res)
 	switch len(res) {
 	case 0:
+		n.ProtocolError()
 		return nil, errors.New("illegal response")
 	case 1:
 		if res[0] != "WELCOME" {
@@ -233,14 +350,19 @@ func (n *Node) getNode() (*Node, error) {
 		return nil, err
 	}
 	if len(res) == 0 {
+		n.ProtocolError()
 		return nil, errors.New("no response")
 	}
-	return New(res[0])
+	nn, err := New(res[0])
+	if err != nil {
+		n.ProtocolError()
+	}
+	return nn, err
 }
 
 //Bye says goodBye to n and returns true if success.
 func (n *Node) Bye() bool {
-	res, err := n.Talk("/bye/"+Me(true).Toxstring(), nil)
+	res, err := n.Talk("/bye/"+MeFor(n, true).Toxstring(), nil)
 	if err != nil {
 		log.Println("/bye", n.Nodestr, "error")
 		return false
@@ -351,6 +473,13 @@ func (ns Slice) Extend(a Slice) Slice {
 
 // Me converts myself to *Node.
 func Me(servernameIfExist bool) *Node {
+	if r := myself.GetRelayNode(); r != "" {
+		n, err := New(r)
+		if err == nil {
+			return n
+		}
+		log.Println(err)
+	}
 	ip, port := myself.GetIPPort()
 	var serverName string
 	if servernameIfExist {
@@ -359,10 +488,46 @@ func Me(servernameIfExist bool) *Node {
 	if serverName == "" {
 		serverName = ip
 	}
+	return makeMe(serverName, port)
+}
 
-	n, err := New(fmt.Sprintf("%s:%d%s", serverName, port, cfg.ServerURL))
+//makeMe returns mynode with host and port.
+func makeMe(host string, port int32) *Node {
+	n, err := New(net.JoinHostPort(host, strconv.Itoa(int(port))) + cfg.ServerURL)
 	if err != nil {
 		log.Fatal(err)
 	}
 	return n
 }
+
+//MeFor returns mynode which is told to n.
+//If n is specified by IP, address of mynode in the same family is used,
+//or host is left empty so that n uses the remote address.
+func MeFor(n *Node, servernameIfExist bool) *Node {
+	ip := n.IP()
+	if ip == nil || myself.GetRelayNode() != "" || (servernameIfExist && cfg.ServerName != "") {
+		return Me(servernameIfExist)
+	}
+	_, port := myself.GetIPPort()
+	var host string
+	for _, myip := range myself.GetIPs() {
+		if (net.ParseIP(myip).To4() == nil) == (ip.To4() == nil) {
+			host = myip
+		}
+	}
+	return makeMe(host, port)
+}
+
+//IsMe returns true if n is one of addresses of mynode.
+func (n *Node) IsMe() bool {
+	if n.Equals(Me(false)) || n.Equals(Me(true)) {
+		return true
+	}
+	_, port := myself.GetIPPort()
+	for _, ip := range myself.GetIPs() {
+		if n.Equals(makeMe(ip, port)) {
+			return true
+		}
+	}
+	return false
+}
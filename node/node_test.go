@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package node
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shingetsu-gou/shingetsu-gou/cfg"
+	"github.com/shingetsu-gou/shingetsu-gou/db"
+)
+
+//setupDB opens a new db in a temporary directory and returns the directory.
+func setupDB(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "node")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.RunDir = dir
+	db.Setup()
+	return dir
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Now()
+	for v, d := range map[string]time.Duration{
+		"120": 2 * time.Minute,
+		now.Add(time.Hour).UTC().Format(http.TimeFormat): time.Hour,
+		"":        defaultRetryAfter,
+		"illegal": defaultRetryAfter,
+	} {
+		if r := parseRetryAfter(v, now); r < d-time.Second || r > d {
+			t.Error("illegal duration", v, r)
+		}
+	}
+}
+
+func TestTooManyRequests(t *testing.T) {
+	dir := setupDB(t)
+	defer os.RemoveAll(dir)
+	defer db.DB.Close()
+	var n int
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n++
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer s.Close()
+	nd, err := New(strings.TrimPrefix(s.URL, "http://") + "/server.cgi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < maxFails+1; i++ {
+		if _, err = nd.Talk("/ping", nil); err != ErrTooManyRequests {
+			t.Fatal("illegal error", err)
+		}
+	}
+	if n != 1 {
+		t.Error("talked while throttled", n)
+	}
+	if h := nd.Health(); h.Fails != 0 || nd.IsDead() {
+		t.Error("429 is regarded as a failure", h.Fails)
+	}
+}
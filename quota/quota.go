@@ -0,0 +1,156 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package quota
+
+import (
+	"log"
+	"sort"
+
+	"github.com/boltdb/bolt"
+	"github.com/shingetsu-gou/shingetsu-gou/cfg"
+	"github.com/shingetsu-gou/shingetsu-gou/db"
+	"github.com/shingetsu-gou/shingetsu-gou/mch/keylib"
+	"github.com/shingetsu-gou/shingetsu-gou/node/manager"
+	"github.com/shingetsu-gou/shingetsu-gou/tag/suggest"
+	"github.com/shingetsu-gou/shingetsu-gou/tag/user"
+	"github.com/shingetsu-gou/shingetsu-gou/thread"
+	"github.com/shingetsu-gou/shingetsu-gou/util"
+)
+
+//candidate represents a thread which can be evicted.
+type candidate struct {
+	datfile  string
+	size     int64
+	accessed int64
+}
+
+//candidates is slice of candidate for sorting by accessed time.
+type candidates []*candidate
+
+//Len returns length of candidates.
+func (c candidates) Len() int {
+	return len(c)
+}
+
+//Swap swaps c[i] and c[j].
+func (c candidates) Swap(i, j int) {
+	c[i], c[j] = c[j], c[i]
+}
+
+//Less returns true if c[i] is accessed before c[j].
+func (c candidates) Less(i, j int) bool {
+	return c[i].accessed < c[j].accessed
+}
+
+//evictable returns candidates which should be evicted to reduce total size to quota,
+//least recently used first.
+func evictable(cs candidates, total, quota int64) candidates {
+	sort.Sort(cs)
+	var r candidates
+	for _, c := range cs {
+		if total <= quota {
+			break
+		}
+		r = append(r, c)
+		total -= c.size
+	}
+	return r
+}
+
+//Protected returns true if thread datfile must not be evicted,
+//i.e. it is listed in the pinned list or has user tags.
+func Protected(datfile string) bool {
+	if user.Len(datfile) > 0 {
+		return true
+	}
+	title := util.FileDecode(datfile)
+	for _, p := range cfg.Pinned.GetData() {
+		if p == datfile || p == title {
+			return true
+		}
+	}
+	return false
+}
+
+//Usage returns total size of records in all threads in bytes.
+func Usage() int64 {
+	var total int64
+	for _, c := range thread.AllCaches() {
+		total += c.Size()
+	}
+	return total
+}
+
+//Run evicts threads which are not viewed or updated for the longest time
+//until the total size of records is less than storage_quota.
+//pinned threads and threads with user tags are never evicted.
+func Run() {
+	if cfg.StorageQuota <= 0 {
+		return
+	}
+	var total int64
+	var cs candidates
+	for _, c := range thread.AllCaches() {
+		size := c.Size()
+		total += size
+		if Protected(c.Datfile) {
+			continue
+		}
+		cs = append(cs, &candidate{
+			datfile:  c.Datfile,
+			size:     size,
+			accessed: c.Accessed(),
+		})
+	}
+	for _, c := range evictable(cs, total, cfg.StorageQuota<<20) {
+		if err := evict(c.datfile); err != nil {
+			log.Println(err)
+			continue
+		}
+		log.Println(c.datfile, "was evicted,", c.size, "bytes")
+	}
+}
+
+//evict removes thread datfile with its entries in the lookup table,
+//suggested tags and keylib, and marks it as evicted so that it is not
+//subscribed from recentlist again.
+func evict(datfile string) error {
+	return db.DB.Update(func(tx *bolt.Tx) error {
+		if err := thread.NewCache(datfile).EvictTX(tx); err != nil {
+			return err
+		}
+		if err := manager.RemoveThreadTX(tx, datfile); err != nil {
+			return err
+		}
+		if err := suggest.RemoveTX(tx, datfile); err != nil {
+			return err
+		}
+		return keylib.RemoveTX(tx, datfile)
+	})
+}
@@ -29,6 +29,7 @@
 package recentlist
 
 import (
+	"context"
 	"errors"
 	"log"
 	"strconv"
@@ -123,6 +124,11 @@ func Append(rec *record.Head) {
 	}
 }
 
+//AppendTX add a infos generated from the record in a transaction.
+func AppendTX(tx *bolt.Tx, rec *record.Head) error {
+	return db.Put(tx, "recent", rec.ToKey(), rec)
+}
+
 //find finds records and returns index. returns -1 if not found.
 func find(rec *record.Head) bool {
 	k := rec.ToKey()
@@ -173,8 +179,9 @@ func RemoveOlds() {
 //Getall retrieves Recent records from nodes in searchlist and stores them.
 //tags are shuffled and truncated to tagsize and stored to sugtags in cache.
 //also source nodes are stored into lookuptable.
-//also tags which Recentlist doen't have in sugtagtable are truncated
-func Getall(all bool) {
+//also tags which Recentlist doen't have in sugtagtable are truncated.
+//talking with nodes is canceled when ctx is done.
+func Getall(ctx context.Context, all bool) {
 	const searchNodes = 100
 
 	var begin int64
@@ -185,19 +192,21 @@ func Getall(all bool) {
 	var wg sync.WaitGroup
 	for _, n := range nodes {
 		wg.Add(1)
-		go get(begin, &wg, n)
+		go get(ctx, begin, &wg, n)
 	}
 	wg.Wait()
 	suggest.Prune(GetRecords())
 }
 
-func get(begin int64, wg *sync.WaitGroup, n *node.Node) {
+func get(ctx context.Context, begin int64, wg *sync.WaitGroup, n *node.Node) {
 	defer wg.Done()
 	var res []string
 	var err error
-	res, err = n.Talk("/recent/"+strconv.FormatInt(begin, 10)+"-", nil)
+	res, err = n.TalkContext(ctx, "/recent/"+strconv.FormatInt(begin, 10)+"-", nil)
 	if err != nil {
-		manager.RemoveFromAllTable(n)
+		if n.IsDead() {
+			manager.RemoveFromAllTable(n)
+		}
 		log.Println(err)
 		return
 	}
@@ -76,8 +76,7 @@ func (u *Head) Remove() error {
 		if err != nil {
 			return err
 		}
-		d.Deleted = true
-		return d.Put(tx)
+		return d.markDeleted(tx)
 	})
 	if err != nil {
 		log.Print(err)
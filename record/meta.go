@@ -0,0 +1,257 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package record
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/shingetsu-gou/shingetsu-gou/db"
+)
+
+//histDays is # of days of the post histogram in Meta.
+const histDays = 7
+
+//Meta represents aggregate data of records in one thread.
+type Meta struct {
+	Stamp   int64         //latest stamp of alive records
+	Alive   int           //# of alive records
+	Removed int           //# of removed records
+	Size    int64         //sum of body length of all records
+	Hist    map[int64]int //# of alive records per day(unixtime/86400)
+	Updated int64         //time when records or tags of the thread were changed last
+}
+
+//day returns the day number of stamp.
+func day(stamp int64) int64 {
+	return stamp / (24 * 60 * 60)
+}
+
+//Velocity returns # of alive records in histDays.
+func (m *Meta) Velocity() int {
+	today := day(time.Now().Unix())
+	cnt := 0
+	for d, n := range m.Hist {
+		if d > today-histDays {
+			cnt += n
+		}
+	}
+	return cnt
+}
+
+//Modified returns the time when the info of the thread was changed last at now,
+//or 0 if unknown. the velocity may be changed at the start of a day.
+func (m *Meta) Modified(now int64) int64 {
+	if m.Updated <= 0 {
+		return 0
+	}
+	if today := day(now) * 24 * 60 * 60; len(m.Hist) > 0 && m.Updated < today {
+		return today
+	}
+	return m.Updated
+}
+
+//hist adds n to the histogram if stamp is in histDays.
+func (m *Meta) hist(stamp int64, n int) {
+	today := day(time.Now().Unix())
+	if m.Hist == nil {
+		m.Hist = make(map[int64]int)
+	}
+	for d := range m.Hist {
+		if d <= today-histDays {
+			delete(m.Hist, d)
+		}
+	}
+	if d := day(stamp); d > today-histDays {
+		m.Hist[d] += n
+		if m.Hist[d] <= 0 {
+			delete(m.Hist, d)
+		}
+	}
+}
+
+//GetMetaTX returns meta data of thread datfile.
+//returns empty meta if not found.
+func GetMetaTX(tx *bolt.Tx, datfile string) *Meta {
+	m := Meta{}
+	if _, err := db.Get(tx, "threadMeta", []byte(datfile), &m); err != nil {
+		return &Meta{}
+	}
+	return &m
+}
+
+//GetMeta returns meta data of thread datfile.
+func GetMeta(datfile string) *Meta {
+	var m *Meta
+	err := db.DB.View(func(tx *bolt.Tx) error {
+		m = GetMetaTX(tx, datfile)
+		return nil
+	})
+	if err != nil {
+		log.Println(err)
+	}
+	return m
+}
+
+//putMeta saves meta data, or deletes it if no records.
+func putMeta(tx *bolt.Tx, datfile string, m *Meta) error {
+	if m.Alive+m.Removed <= 0 {
+		if tx.Bucket([]byte("threadMeta")) == nil {
+			return nil
+		}
+		return db.Del(tx, "threadMeta", []byte(datfile))
+	}
+	return db.Put(tx, "threadMeta", []byte(datfile), m)
+}
+
+//TouchMetaTX records that the thread datfile was changed now if it has records.
+func TouchMetaTX(tx *bolt.Tx, datfile string) error {
+	m := GetMetaTX(tx, datfile)
+	if m.Alive+m.Removed <= 0 {
+		return nil
+	}
+	m.Updated = time.Now().Unix()
+	return putMeta(tx, datfile, m)
+}
+
+//latestStamp returns the latest stamp of alive records in thread datfile.
+func latestStamp(tx *bolt.Tx, datfile string) (int64, error) {
+	b := tx.Bucket([]byte("record"))
+	if b == nil {
+		return 0, nil
+	}
+	prefix := db.ToKey(datfile)
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+	end[len(end)-1] = 0x01
+	c := b.Cursor()
+	k, v := c.Seek(end)
+	if k == nil {
+		k, v = c.Last()
+	} else {
+		k, v = c.Prev()
+	}
+	for ; bytes.HasPrefix(k, prefix); k, v = c.Prev() {
+		d := DB{}
+		if err := json.Unmarshal(v, &d); err != nil {
+			return 0, err
+		}
+		if !d.Deleted {
+			return d.Stamp, nil
+		}
+	}
+	return 0, nil
+}
+
+//addMeta updates meta data of the thread by adding record d.
+func (d *DB) addMeta(tx *bolt.Tx) error {
+	m := GetMetaTX(tx, d.Datfile)
+	m.Updated = time.Now().Unix()
+	m.Size += d.size(tx)
+	if d.Deleted {
+		m.Removed++
+	} else {
+		m.Alive++
+		m.hist(d.Stamp, 1)
+		if m.Stamp < d.Stamp {
+			m.Stamp = d.Stamp
+		}
+	}
+	return putMeta(tx, d.Datfile, m)
+}
+
+//delMeta updates meta data of the thread by deleting record d from db.
+//must be called after d is deleted.
+func (d *DB) delMeta(tx *bolt.Tx) error {
+	m := GetMetaTX(tx, d.Datfile)
+	m.Updated = time.Now().Unix()
+	m.Size -= d.size(tx)
+	if d.Deleted {
+		m.Removed--
+		return putMeta(tx, d.Datfile, m)
+	}
+	m.Alive--
+	m.hist(d.Stamp, -1)
+	var err error
+	if m.Stamp, err = latestStamp(tx, d.Datfile); err != nil {
+		return err
+	}
+	return putMeta(tx, d.Datfile, m)
+}
+
+//removeMeta updates meta data of the thread by marking record d as removed.
+//must be called after d is saved as removed.
+func (d *DB) removeMeta(tx *bolt.Tx) error {
+	m := GetMetaTX(tx, d.Datfile)
+	m.Updated = time.Now().Unix()
+	m.Alive--
+	m.Removed++
+	m.hist(d.Stamp, -1)
+	var err error
+	if m.Stamp, err = latestStamp(tx, d.Datfile); err != nil {
+		return err
+	}
+	return putMeta(tx, d.Datfile, m)
+}
+
+//markDeleted saves d as removed and updates the search index and meta data.
+func (d *DB) markDeleted(tx *bolt.Tx) error {
+	if d.Deleted {
+		return nil
+	}
+	if err := d.removeIndex(tx); err != nil {
+		return err
+	}
+	d.Deleted = true
+	if err := d.Put(tx); err != nil {
+		return err
+	}
+	tx.OnCommit(func() {
+		removeThumbnails(d.Head)
+	})
+	return d.removeMeta(tx)
+}
+
+//makeMeta makes meta data of all threads.
+func makeMeta(tx *bolt.Tx) error {
+	if tx.Bucket([]byte("threadMeta")) != nil {
+		if err := tx.DeleteBucket([]byte("threadMeta")); err != nil {
+			return err
+		}
+	}
+	if tx.Bucket([]byte("record")) == nil {
+		return nil
+	}
+	return ForEach(tx, func(d *DB) error {
+		return d.addMeta(tx)
+	})
+}
@@ -30,36 +30,229 @@ package record
 
 import (
 	"bytes"
+	"encoding/base64"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"html"
 	"log"
-	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"encoding/json"
 
 	"github.com/boltdb/bolt"
+	"github.com/shingetsu-gou/shingetsu-gou/blob"
 	"github.com/shingetsu-gou/shingetsu-gou/cfg"
 	"github.com/shingetsu-gou/shingetsu-gou/db"
+	"github.com/shingetsu-gou/shingetsu-gou/hub"
 	"github.com/shingetsu-gou/shingetsu-gou/node"
+	"github.com/shingetsu-gou/shingetsu-gou/search"
 	"github.com/shingetsu-gou/shingetsu-gou/util"
 )
 
 var cachedRule *util.RegexpList
 
+//ErrBadID is returned if the ID of a record doesn't match md5 of its body.
+var ErrBadID = errors.New("ID doesn't match md5 of the body")
+
+func init() {
+	db.RegistMigration(1, "make search index", makeIndex)
+	db.RegistMigration(2, "make meta data of threads", makeMeta)
+	db.RegistMigration(3, "move attached files to the blob store", splitAttaches)
+}
+
+const (
+	//NotSigned represents the record has no signature.
+	NotSigned = iota
+	//SignOK represents the signature of the record is valid.
+	SignOK
+	//SignNG represents the signature of the record is forged.
+	SignNG
+)
+
 //DB represents one record in db.
+//if the record has an attached file, the file is saved in the blob store
+//and the value of attach in Body is empty.
 type DB struct {
 	*Head
 	Body    string
 	Deleted bool
+	Forged  bool
+	Attach  string `json:",omitempty"` //hash of the attached file in the blob store.
 }
 
 //Del deletes data from db.
-func (d *DB) Del(tx *bolt.Tx) {
+func (d *DB) Del(tx *bolt.Tx) error {
+	if !d.Deleted {
+		if err := d.removeIndex(tx); err != nil {
+			return err
+		}
+	}
 	if err := db.Del(tx, "record", d.Head.ToKey()); err != nil {
-		log.Println(err)
+		return err
+	}
+	if err := d.delMeta(tx); err != nil {
+		return err
+	}
+	tx.OnCommit(func() {
+		removeThumbnails(d.Head)
+	})
+	if d.Attach == "" {
+		return nil
+	}
+	return blob.ReleaseTX(tx, d.Attach)
+}
+
+//splitAttachTX moves the attached file in Body to the blob store.
+//the file is kept in Body if its base64 is not canonical, because it cannot be restored as it is.
+func (d *DB) splitAttachTX(tx *bolt.Tx) error {
+	if d.Attach != "" {
+		return nil
+	}
+	kvs := strings.Split(d.Body, "<>")
+	for i, kv := range kvs {
+		if !strings.HasPrefix(kv, "attach:") || kv == "attach:" {
+			continue
+		}
+		encoded := kv[len("attach:"):]
+		data, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			log.Println(d.Idstr(), err)
+			return nil
+		}
+		if base64.StdEncoding.EncodeToString(data) != encoded {
+			log.Println(d.Idstr(), "attached file is not encoded canonically, kept inline")
+			return nil
+		}
+		if d.Attach, err = blob.PutTX(tx, data); err != nil {
+			return err
+		}
+		kvs[i] = "attach:"
+		d.Body = strings.Join(kvs, "<>")
+		return nil
+	}
+	return nil
+}
+
+//InlineBodyTX returns Body in which the attached file is restored from the blob store.
+func (d *DB) InlineBodyTX(tx *bolt.Tx) (string, error) {
+	if d.Attach == "" {
+		return d.Body, nil
+	}
+	data, err := blob.GetTX(tx, d.Attach)
+	if err != nil {
+		return "", err
+	}
+	kvs := strings.Split(d.Body, "<>")
+	for i, kv := range kvs {
+		if kv == "attach:" {
+			kvs[i] = "attach:" + base64.StdEncoding.EncodeToString(data)
+			break
+		}
+	}
+	return strings.Join(kvs, "<>"), nil
+}
+
+//VerifyTX returns ErrBadID if the ID of the record doesn't match md5 of its body
+//including the attached file.
+func (d *DB) VerifyTX(tx *bolt.Tx) error {
+	body, err := d.InlineBodyTX(tx)
+	if err != nil {
+		return err
+	}
+	r := New(d.Datfile, d.ID, d.Stamp)
+	if err := r.Parse(fmt.Sprintf("%d<>%s<>%s", d.Stamp, d.ID, body)); err != nil {
+		return err
+	}
+	if !r.md5check() {
+		return ErrBadID
+	}
+	return nil
+}
+
+//size returns the size of the record including the attached file in base64.
+func (d *DB) size(tx *bolt.Tx) int64 {
+	size := int64(len(d.Body))
+	if d.Attach != "" {
+		size += int64(base64.StdEncoding.EncodedLen(blob.LenTX(tx, d.Attach)))
+	}
+	return size
+}
+
+//splitAttaches moves attached files of all records to the blob store.
+func splitAttaches(tx *bolt.Tx) error {
+	if tx.Bucket([]byte("record")) == nil {
+		return nil
+	}
+	var heads []*Head
+	err := ForEach(tx, func(d *DB) error {
+		if d.Attach == "" && strings.Contains(d.Body, "attach:") {
+			h := *d.Head
+			heads = append(heads, &h)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, h := range heads {
+		d, err := GetFromDB(tx, h)
+		if err != nil {
+			return err
+		}
+		if err := d.splitAttachTX(tx); err != nil {
+			return err
+		}
+		if err := d.Put(tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//indexText returns text to be indexed from body.
+func (d *DB) indexText() string {
+	var r []string
+	for _, kv := range strings.Split(d.Body, "<>") {
+		buf := strings.SplitN(kv, ":", 2)
+		if len(buf) == 2 && (buf[0] == "body" || buf[0] == "name") {
+			r = append(r, html.UnescapeString(strings.Replace(buf[1], "<br>", "\n", -1)))
+		}
+	}
+	return strings.Join(r, "\n")
+}
+
+//addIndex adds the record to the search index.
+func (d *DB) addIndex(tx *bolt.Tx) error {
+	return search.AddTX(tx, d.Datfile, d.Stamp, d.ID, d.indexText())
+}
+
+//removeIndex removes the record from the search index.
+func (d *DB) removeIndex(tx *bolt.Tx) error {
+	return search.RemoveTX(tx, d.Datfile, d.Stamp, d.ID, d.indexText())
+}
+
+//makeIndex makes the search index from all records.
+func makeIndex(tx *bolt.Tx) error {
+	for _, b := range []string{"wordIndex", "wordDF"} {
+		if tx.Bucket([]byte(b)) == nil {
+			continue
+		}
+		if err := tx.DeleteBucket([]byte(b)); err != nil {
+			return err
+		}
+	}
+	if tx.Bucket([]byte("record")) == nil {
+		return nil
 	}
+	return ForEach(tx, func(d *DB) error {
+		if d.Deleted {
+			return nil
+		}
+		return d.addIndex(tx)
+	})
 }
 
 //Put puts this one to db.
@@ -96,6 +289,34 @@ func GetFromDBs(tx *bolt.Tx, datfile string) ([]*DB, error) {
 
 }
 
+//GetHeadsTX returns heads of records in begin~end whose thread name is datfile.
+//heads are read from keys without decoding records, and sorted by stamp.
+func GetHeadsTX(tx *bolt.Tx, datfile string, begin, end int64) ([]*Head, error) {
+	b := tx.Bucket([]byte("record"))
+	if b == nil {
+		return nil, errors.New("bucket not found record")
+	}
+	prefix := db.ToKey(datfile)
+	start := prefix
+	if begin > 0 {
+		start = db.ToKey(datfile, begin)
+	}
+	var hs []*Head
+	c := b.Cursor()
+	for k, _ := c.Seek(start); bytes.HasPrefix(k, prefix) && len(k) >= len(prefix)+8; k, _ = c.Next() {
+		stamp := int64(binary.BigEndian.Uint64(k[len(prefix):]))
+		if stamp > end {
+			break
+		}
+		hs = append(hs, &Head{
+			Datfile: datfile,
+			Stamp:   stamp,
+			ID:      string(bytes.TrimSuffix(k[len(prefix)+8:], []byte{0x00})),
+		})
+	}
+	return hs, nil
+}
+
 //ForEach do eachDo for each k/v to "record" db.
 func ForEach(tx *bolt.Tx, eachDo func(*DB) error) error {
 	b := tx.Bucket([]byte("record"))
@@ -103,8 +324,8 @@ func ForEach(tx *bolt.Tx, eachDo func(*DB) error) error {
 		return errors.New("bucket not found record")
 	}
 	c := b.Cursor()
-	d := DB{}
 	for k, v := c.First(); k != nil; k, v = c.Next() {
+		d := DB{}
 		if errr := json.Unmarshal(v, &d); errr != nil {
 			return errr
 		}
@@ -121,6 +342,7 @@ type Record struct {
 	*Head
 	contents map[string]string
 	keyOrder []string
+	attach   string //hash of the attached file in the blob store.
 }
 
 //NewIDstr parse idstr unixtime+"_"+md5(bodystr)), set stamp and id, and return record obj.
@@ -254,6 +476,7 @@ func (r *Record) Parse(recstr string) error {
 	}
 	r.contents = make(map[string]string)
 	r.keyOrder = nil
+	r.attach = ""
 	//reposense of recentlist  : stamp<>id<>thread_***<>tag:***
 	//record str : stamp<>id<>body:***<>...
 	for _, kv := range tmp[2:] {
@@ -277,7 +500,20 @@ func (r *Record) Parse(recstr string) error {
 }
 
 //Load loads a record file and parses it.
+//the attached file is left in the blob store, so use AttachData to read it.
 func (r *Record) Load() error {
+	return r.load(false)
+}
+
+//LoadInline loads a record file like Load, and restores the attached file in base64
+//in its body as in the wire format.
+func (r *Record) LoadInline() error {
+	return r.load(true)
+}
+
+//load loads a record file and parses it.
+//if inline, the attached file is restored in the body.
+func (r *Record) load(inline bool) error {
 	if !r.Exists() {
 		err := r.Remove()
 		if err != nil {
@@ -286,16 +522,52 @@ func (r *Record) Load() error {
 		return errors.New("file not found")
 	}
 	var d *DB
+	var body string
 	err := db.DB.View(func(tx *bolt.Tx) error {
 		var err error
-		d, err = GetFromDB(tx, r.Head)
+		if d, err = GetFromDB(tx, r.Head); err != nil {
+			return err
+		}
+		if !inline {
+			body = d.Body
+			return nil
+		}
+		body, err = d.InlineBodyTX(tx)
 		return err
 	})
 	if err != nil {
 		log.Println(err)
 		return err
 	}
-	return r.Parse(fmt.Sprintf("%d<>%s<>%s", r.Stamp, r.ID, d.Body))
+	if err := r.Parse(fmt.Sprintf("%d<>%s<>%s", r.Stamp, r.ID, body)); err != nil {
+		return err
+	}
+	if !inline {
+		r.attach = d.Attach
+	}
+	return nil
+}
+
+//AttachData returns the decoded attached file.
+//the file is read from the blob store without decoding if the record is loaded from db.
+func (r *Record) AttachData() ([]byte, error) {
+	if r.attach != "" {
+		return blob.Get(r.attach)
+	}
+	return base64.StdEncoding.DecodeString(r.GetBodyValue("attach", ""))
+}
+
+//HasAttach returns true if the record has an attached file.
+func (r *Record) HasAttach() bool {
+	return r.attach != "" || r.GetBodyValue("attach", "") != ""
+}
+
+//AttachSize returns the size of the decoded attached file.
+func (r *Record) AttachSize() int {
+	if r.attach != "" {
+		return blob.Len(r.attach)
+	}
+	return base64.StdEncoding.DecodedLen(len(r.GetBodyValue("attach", "")))
 }
 
 //ShortPubkey returns short version of pubkey.
@@ -338,6 +610,41 @@ func (r *Record) Build(stamp int64, body map[string]string, passwd string) strin
 	return r.ID
 }
 
+//CheckSign verifies the signature of the record over the keys listed in target.
+//the attached file is read from the blob store if the record is loaded from db.
+//returns NotSigned if the record doesn't have pubkey, sign and target.
+func (r *Record) CheckSign() int {
+	pubkey := r.GetBodyValue("pubkey", "")
+	sign := r.GetBodyValue("sign", "")
+	target := r.GetBodyValue("target", "")
+	if pubkey == "" && sign == "" && target == "" {
+		return NotSigned
+	}
+	if pubkey == "" || sign == "" || target == "" {
+		return SignNG
+	}
+	keys := strings.Split(target, ",")
+	rs := make([]string, len(keys))
+	for i, k := range keys {
+		v, exist := r.contents[k]
+		if !exist || k == "pubkey" || k == "sign" || k == "target" {
+			return SignNG
+		}
+		if k == "attach" && r.attach != "" {
+			data, err := blob.Get(r.attach)
+			if err != nil {
+				return SignNG
+			}
+			v = base64.StdEncoding.EncodeToString(data)
+		}
+		rs[i] = k + ":" + v
+	}
+	if !util.Verify(util.MD5digest(strings.Join(rs, "<>")), sign, pubkey) {
+		return SignNG
+	}
+	return SignOK
+}
+
 //md5check return true if md5 of bodystr is same as r.id.
 func (r *Record) md5check() bool {
 	return util.MD5digest(r.bodystr()) == r.ID
@@ -350,16 +657,17 @@ func (r *Record) AttachPath(thumbnailSize string) string {
 	if suffix == "" {
 		return ""
 	}
-	reg := regexp.MustCompile(`[^-_.A-Za-z0-9]`)
-	reg.ReplaceAllString(suffix, "")
+	suffix = reSuffix.ReplaceAllString(suffix, "")
 	if thumbnailSize != "" {
 		return "s" + r.Idstr() + "." + thumbnailSize + "." + suffix
 	}
 	return r.Idstr() + "." + suffix
 }
 
-//SyncTX saves Recstr to the file. if attached file exists, saves it to attached path.
+//SyncTX saves Recstr to the file. if attached file exists, saves it to the blob store.
 //if signed, also saves body part.
+//records with forged signature are saved as deleted.
+//new records which are not deleted are published to the hub when tx is committed.
 func (r *Record) SyncTX(tx *bolt.Tx, deleted bool) error {
 	has, err := db.HasKey(tx, "record", r.Head.ToKey())
 	if err != nil {
@@ -368,12 +676,193 @@ func (r *Record) SyncTX(tx *bolt.Tx, deleted bool) error {
 	if has {
 		return nil
 	}
+	forged := r.CheckSign() == SignNG
 	d := DB{
 		Head:    r.Head,
 		Body:    r.bodystr(),
-		Deleted: deleted,
+		Deleted: deleted || forged,
+		Forged:  forged,
+	}
+	if err := d.splitAttachTX(tx); err != nil {
+		return err
+	}
+	if err := d.Put(tx); err != nil {
+		return err
+	}
+	if err := d.addMeta(tx); err != nil {
+		return err
+	}
+	if d.Deleted {
+		return nil
+	}
+	h := r.CopyHead()
+	tx.OnCommit(func() {
+		hub.Publish(h.Datfile, &h)
+	})
+	if err := d.addIndex(tx); err != nil {
+		return err
+	}
+	return d.removePendingTX(tx)
+}
+
+//pending is a deletion notice whose target is not received yet.
+type pending struct {
+	*Head
+	Received int64
+}
+
+//pendingKey returns the key of the deletion notice n of the target t in pendingRemoval,
+//which starts with the key of t so that notices of t can be found by prefix.
+func pendingKey(t, n *Head) []byte {
+	return append(t.ToKey(), n.ToKey()...)
+}
+
+//removePendingTX applies deletion notices of d which were received before d.
+func (d *DB) removePendingTX(tx *bolt.Tx) error {
+	b := tx.Bucket([]byte("pendingRemoval"))
+	if b == nil {
+		return nil
 	}
-	return d.Put(tx)
+	prefix := d.ToKey()
+	var keys [][]byte
+	var ps []*pending
+	c := b.Cursor()
+	for k, v := c.Seek(prefix); bytes.HasPrefix(k, prefix); k, v = c.Next() {
+		keys = append(keys, k)
+		p := &pending{}
+		if err := json.Unmarshal(v, p); err != nil || p.Head == nil {
+			log.Println("illegal deletion notice of", d.Idstr())
+			continue
+		}
+		ps = append(ps, p)
+	}
+	for _, k := range keys {
+		if err := b.Delete(k); err != nil {
+			return err
+		}
+	}
+	for _, p := range ps {
+		n, err := GetFromDB(tx, p.Head)
+		if err != nil {
+			log.Println("deletion notice", p.Idstr(), "was removed")
+			continue
+		}
+		notice := New(p.Datfile, p.ID, p.Stamp)
+		if err := notice.Parse(fmt.Sprintf("%d<>%s<>%s", p.Stamp, p.ID, n.Body)); err != nil {
+			return err
+		}
+		if err := notice.RemoveTargetTX(tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//RemovePendingsTX removes deletion notices of records in thread datfile
+//whose targets are not received yet.
+func RemovePendingsTX(tx *bolt.Tx, datfile string) error {
+	b := tx.Bucket([]byte("pendingRemoval"))
+	if b == nil {
+		return nil
+	}
+	prefix := db.ToKey(datfile)
+	var keys [][]byte
+	c := b.Cursor()
+	for k, _ := c.Seek(prefix); bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+		keys = append(keys, k)
+	}
+	for _, k := range keys {
+		if err := b.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//PendingExpired returns true if the deletion notice v in pendingRemoval was received
+//before save_removed at now, or cannot be decoded.
+func PendingExpired(v []byte, now int64) bool {
+	p := &pending{}
+	if err := json.Unmarshal(v, p); err != nil || p.Head == nil {
+		return true
+	}
+	return cfg.SaveRemoved > 0 && p.Received < now-cfg.SaveRemoved
+}
+
+//RemoveExpiredPendings removes deletion notices whose targets are not received
+//within save_removed.
+func RemoveExpiredPendings() {
+	err := db.DB.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte("pendingRemoval"))
+		if b == nil {
+			return nil
+		}
+		now := time.Now().Unix()
+		var keys [][]byte
+		err := b.ForEach(func(k, v []byte) error {
+			if PendingExpired(v, now) {
+				keys = append(keys, k)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, k := range keys {
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+		}
+		log.Println(len(keys), "expired deletion notices were removed")
+		return nil
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+//RemoveTargetTX marks the record specified by remove_stamp and remove_id as deleted
+//if r is a deletion notice signed by the author of the target or by one of moderators.
+//remove_stamp and remove_id must be signed.
+//if the target is not received yet, the notice is kept until it is received.
+func (r *Record) RemoveTargetTX(tx *bolt.Tx) error {
+	rstamp := r.GetBodyValue("remove_stamp", "")
+	rid := r.GetBodyValue("remove_id", "")
+	if rstamp == "" || rid == "" || r.CheckSign() != SignOK {
+		return nil
+	}
+	keys := strings.Split(r.GetBodyValue("target", ""), ",")
+	if !util.HasString(keys, "remove_stamp") || !util.HasString(keys, "remove_id") {
+		log.Println("deletion notice", r.Idstr(), "doesn't sign its target")
+		return nil
+	}
+	stamp, err := strconv.ParseInt(rstamp, 10, 64)
+	if err != nil {
+		return err
+	}
+	th := &Head{Datfile: r.Datfile, Stamp: stamp, ID: rid}
+	d, err := GetFromDB(tx, th)
+	if err != nil {
+		log.Println("target of deletion notice", rstamp, rid, "is not received yet")
+		return db.Put(tx, "pendingRemoval", pendingKey(th, r.Head), &pending{
+			Head:     r.Head,
+			Received: time.Now().Unix(),
+		})
+	}
+	if d.Deleted {
+		return nil
+	}
+	target := New(r.Datfile, rid, stamp)
+	if err := target.Parse(fmt.Sprintf("%d<>%s<>%s", stamp, rid, d.Body)); err != nil {
+		return err
+	}
+	pubkey := r.GetBodyValue("pubkey", "")
+	if pubkey != target.GetBodyValue("pubkey", "") && !util.HasString(cfg.Moderators.GetData(), pubkey) {
+		log.Println("deletion notice", r.Idstr(), "is not signed by the author or moderators")
+		return nil
+	}
+	log.Println("removing", target.Idstr(), "by deletion notice", r.Idstr())
+	return d.markDeleted(tx)
 }
 
 //Sync saves Recstr to the file. if attached file exists, saves it to attached path.
@@ -421,7 +910,7 @@ func (r *Record) IsSpam() bool {
 
 //MakeAttachLink makes and returns attached file link.
 func (r *Record) MakeAttachLink(sakuHost string) string {
-	if r.GetBodyValue("attach", "") == "" {
+	if !r.HasAttach() {
 		return ""
 	}
 	url := fmt.Sprintf("http://%s/thread.cgi/%s/%s/%d.%s",
@@ -430,7 +919,7 @@ func (r *Record) MakeAttachLink(sakuHost string) string {
 }
 
 //GetData gets records from node n and checks its is same as stamp and id in args.
-//save recs if success. returns errSpam or errGet.
+//save recs if success. returns errSpam, errForged or errGet.
 func (r *Record) GetData(n *node.Node) error {
 	res, err := n.Talk(fmt.Sprintf("/get/%s/%d/%s", r.Datfile, r.Stamp, r.ID), nil)
 	if len(res) == 0 {
@@ -444,17 +933,30 @@ func (r *Record) GetData(n *node.Node) error {
 		return cfg.ErrGet
 	}
 	r.Sync()
-	return r.CheckData(-1, -1)
+	if err = r.CheckData(-1, -1); err != nil {
+		return err
+	}
+	err = db.DB.Update(func(tx *bolt.Tx) error {
+		return r.RemoveTargetTX(tx)
+	})
+	if err != nil {
+		log.Println(err)
+	}
+	return nil
 }
 
 //CheckData makes records from res and checks its records meets condisions of args.
 //adds the rec to cache if meets conditions.
 //if spam or big data, remove the rec from disk.
-//returns count of added records to the cache and spam/getting error.
+//returns count of added records to the cache and spam/forged/getting error.
 func (r *Record) CheckData(begin, end int64) error {
 	if !r.Meets(begin, end) {
 		return cfg.ErrGet
 	}
+	if r.CheckSign() == SignNG {
+		log.Printf("warning:%s/%s:forged signature", r.Datfile, r.Idstr())
+		return cfg.ErrForged
+	}
 	log.Println(r.Recstr(), r.IsSpam())
 	if len(r.Recstr()) > cfg.RecordLimit<<10 || r.IsSpam() {
 		log.Printf("warning:%s/%s:too large or spam record", r.Datfile, r.Idstr())
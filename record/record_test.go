@@ -0,0 +1,378 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package record
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/shingetsu-gou/shingetsu-gou/cfg"
+	"github.com/shingetsu-gou/shingetsu-gou/db"
+	"github.com/shingetsu-gou/shingetsu-gou/util"
+)
+
+func TestGetHeadsTX(t *testing.T) {
+	dir := setupDB(t)
+	defer os.RemoveAll(dir)
+	defer db.DB.Close()
+	var ids []string
+	err := db.DB.Update(func(tx *bolt.Tx) error {
+		for _, h := range []*Head{
+			{Datfile: "thread_a", Stamp: 3},
+			{Datfile: "thread_a", Stamp: 1},
+			{Datfile: "thread_ab", Stamp: 2},
+			{Datfile: "thread_a", Stamp: 2},
+		} {
+			r := New(h.Datfile, "", 0)
+			r.Build(h.Stamp, map[string]string{"body": h.Datfile, "name": string(rune(h.Stamp) + 'a')}, "")
+			if h.Datfile == "thread_a" && h.Stamp >= 2 {
+				ids = append(ids, r.ID)
+			}
+			if errr := r.SyncTX(tx, false); errr != nil {
+				return errr
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = db.DB.View(func(tx *bolt.Tx) error {
+		hs, errr := GetHeadsTX(tx, "thread_a", 2, 3)
+		if errr != nil {
+			return errr
+		}
+		if len(hs) != 2 || hs[0].Stamp != 2 || hs[0].ID != ids[1] || hs[1].Stamp != 3 || hs[1].ID != ids[0] {
+			t.Error("illegal heads", hs)
+		}
+		if hs, errr = GetHeadsTX(tx, "thread_a", 0, 1); errr != nil || len(hs) != 1 || hs[0].Datfile != "thread_a" {
+			t.Error("illegal heads", hs, errr)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAttachRoundTrip(t *testing.T) {
+	dir := setupDB(t)
+	defer os.RemoveAll(dir)
+	defer db.DB.Close()
+	data := []byte("attached file")
+	for _, c := range []struct {
+		attach string
+		split  bool
+	}{
+		{base64.StdEncoding.EncodeToString(data), true},
+		{"QR==", false}, //non-zero padding bits, decoded as "A" but not canonical.
+	} {
+		r := New("thread_a", "", 0)
+		r.Build(1, map[string]string{"body": "foo", "attach": c.attach, "suffix": "txt"}, "pass")
+		wire := r.Recstr()
+		err := db.DB.Update(func(tx *bolt.Tx) error {
+			return r.SyncTX(tx, false)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var d *DB
+		err = db.DB.View(func(tx *bolt.Tx) error {
+			var errr error
+			d, errr = GetFromDB(tx, r.Head)
+			return errr
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (d.Attach != "") != c.split {
+			t.Error("illegal split of", c.attach, d.Attach)
+		}
+		l := New(r.Datfile, r.ID, r.Stamp)
+		if err = l.Load(); err != nil {
+			t.Fatal(err)
+		}
+		if l.CheckSign() != SignOK {
+			t.Error("signature of the loaded record is NG", c.attach)
+		}
+		if !l.HasAttach() {
+			t.Error("attached file is lost", c.attach)
+		}
+		li := New(r.Datfile, r.ID, r.Stamp)
+		if err = li.LoadInline(); err != nil {
+			t.Fatal(err)
+		}
+		if li.Recstr() != wire || !li.md5check() || li.CheckSign() != SignOK {
+			t.Error("inlined record differs", c.attach)
+		}
+	}
+}
+
+func TestPendingRemoval(t *testing.T) {
+	dir := setupDB(t)
+	defer os.RemoveAll(dir)
+	defer db.DB.Close()
+	cfg.Moderators = util.NewConfList("/nonexistent", nil)
+	target := New("thread_a", "", 0)
+	target.Build(1, map[string]string{"body": "target"}, "author")
+	notice := func(stamp int64, passwd string) *Record {
+		n := New("thread_a", "", 0)
+		n.Build(stamp, map[string]string{
+			"body":         "remove",
+			"remove_stamp": fmt.Sprint(target.Stamp),
+			"remove_id":    target.ID,
+		}, passwd)
+		return n
+	}
+	count := func() int {
+		n := 0
+		err := db.DB.View(func(tx *bolt.Tx) error {
+			if b := tx.Bucket([]byte("pendingRemoval")); b != nil {
+				n = b.Stats().KeyN
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+	err := db.DB.Update(func(tx *bolt.Tx) error {
+		//the notice by the author comes first, and then one by another user.
+		for _, n := range []*Record{notice(2, "author"), notice(3, "other")} {
+			if errr := n.SyncTX(tx, false); errr != nil {
+				return errr
+			}
+			if errr := n.RemoveTargetTX(tx); errr != nil {
+				return errr
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := count(); n != 2 {
+		t.Fatal("notices are not kept", n)
+	}
+	err = db.DB.Update(func(tx *bolt.Tx) error {
+		return target.SyncTX(tx, false)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = db.DB.View(func(tx *bolt.Tx) error {
+		d, errr := GetFromDB(tx, target.Head)
+		if errr != nil {
+			return errr
+		}
+		if !d.Deleted {
+			t.Error("target is not removed by the notice of the author")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := count(); n != 0 {
+		t.Fatal("applied notices are not removed", n)
+	}
+
+	err = db.DB.Update(func(tx *bolt.Tx) error {
+		target = New("thread_b", "", 0)
+		target.Build(1, map[string]string{"body": "target"}, "author")
+		n := New("thread_b", "", 0)
+		n.Build(2, map[string]string{
+			"body":         "remove",
+			"remove_stamp": fmt.Sprint(target.Stamp),
+			"remove_id":    target.ID,
+		}, "author")
+		if errr := n.SyncTX(tx, false); errr != nil {
+			return errr
+		}
+		return n.RemoveTargetTX(tx)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := count(); n != 1 {
+		t.Fatal("notice is not kept", n)
+	}
+	saveRemoved := cfg.SaveRemoved
+	defer func() {
+		cfg.SaveRemoved = saveRemoved
+	}()
+	cfg.SaveRemoved = 1
+	err = db.DB.View(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte("pendingRemoval")).ForEach(func(k, v []byte) error {
+			if PendingExpired(v, time.Now().Unix()) || !PendingExpired(v, time.Now().Unix()+2) {
+				t.Error("illegal expiry")
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = db.DB.Update(func(tx *bolt.Tx) error {
+		return RemovePendingsTX(tx, "thread_b")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := count(); n != 0 {
+		t.Fatal("notices are not removed with the thread", n)
+	}
+}
+
+func TestMetaModified(t *testing.T) {
+	dir := setupDB(t)
+	defer os.RemoveAll(dir)
+	defer db.DB.Close()
+	now := time.Now().Unix()
+	r := New("thread_a", "", 0)
+	r.Build(now, map[string]string{"body": "test"}, "")
+	err := db.DB.Update(func(tx *bolt.Tx) error {
+		if errr := r.SyncTX(tx, false); errr != nil {
+			return errr
+		}
+		m := GetMetaTX(tx, "thread_a")
+		if m.Updated < now {
+			t.Error("updated time is not set", m.Updated)
+		}
+		m.Updated = 1
+		if errr := putMeta(tx, "thread_a", m); errr != nil {
+			return errr
+		}
+		d, errr := GetFromDB(tx, r.Head)
+		if errr != nil {
+			return errr
+		}
+		if errr := d.markDeleted(tx); errr != nil {
+			return errr
+		}
+		if m = GetMetaTX(tx, "thread_a"); m.Updated < now {
+			t.Error("updated time is not changed by removing", m.Updated)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	today := day(now) * 24 * 60 * 60
+	m := &Meta{Updated: today - 10, Hist: map[int64]int{day(now) - 1: 1}}
+	if m.Modified(now) != today {
+		t.Error("velocity change at the start of the day is not considered", m.Modified(now))
+	}
+	if (&Meta{}).Modified(now) != 0 {
+		t.Error("unknown time should be 0")
+	}
+}
+
+func TestCheckSign(t *testing.T) {
+	dir := setupDB(t)
+	defer os.RemoveAll(dir)
+	defer db.DB.Close()
+	other := New("thread_a", "", 0)
+	other.Build(1, map[string]string{"body": "foo"}, "other")
+	attach := base64.StdEncoding.EncodeToString([]byte("attached file"))
+	build := func(body map[string]string, passwd string) *Record {
+		r := New("thread_a", "", 0)
+		r.Build(1, body, passwd)
+		return r
+	}
+	signed := func() *Record {
+		return build(map[string]string{"body": "foo", "name": "bar"}, "pass")
+	}
+	for _, c := range []struct {
+		name   string
+		record func() *Record
+		result int
+	}{
+		{"valid", signed, SignOK},
+		{"not signed", func() *Record {
+			return build(map[string]string{"body": "foo"}, "")
+		}, NotSigned},
+		{"tampered", func() *Record {
+			r := signed()
+			r.contents["body"] = "baz"
+			return r
+		}, SignNG},
+		{"missing target key", func() *Record {
+			r := signed()
+			delete(r.contents, "name")
+			return r
+		}, SignNG},
+		{"signature in target", func() *Record {
+			r := signed()
+			r.contents["target"] += ",sign"
+			return r
+		}, SignNG},
+		{"no sign", func() *Record {
+			r := signed()
+			delete(r.contents, "sign")
+			return r
+		}, SignNG},
+		{"pubkey of another user", func() *Record {
+			r := signed()
+			r.contents["pubkey"] = other.contents["pubkey"]
+			return r
+		}, SignNG},
+		{"broken pubkey", func() *Record {
+			r := signed()
+			r.contents["pubkey"] = "!!"
+			return r
+		}, SignNG},
+		{"inline attach", func() *Record {
+			return build(map[string]string{"body": "foo", "attach": attach, "suffix": "txt"}, "pass")
+		}, SignOK},
+		{"stored attach", func() *Record {
+			r := build(map[string]string{"body": "foo", "attach": attach, "suffix": "txt"}, "pass")
+			r.Sync()
+			l := New(r.Datfile, r.ID, r.Stamp)
+			if err := l.Load(); err != nil {
+				t.Fatal(err)
+			}
+			return l
+		}, SignOK},
+		{"tampered attach", func() *Record {
+			r := build(map[string]string{"body": "foo", "attach": attach, "suffix": "txt"}, "pass")
+			r.contents["attach"] = base64.StdEncoding.EncodeToString([]byte("tampered"))
+			return r
+		}, SignNG},
+	} {
+		if s := c.record().CheckSign(); s != c.result {
+			t.Error(c.name, "result", s, "should be", c.result)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package record
+
+import (
+	"errors"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/shingetsu-gou/shingetsu-gou/cfg"
+	"github.com/shingetsu-gou/shingetsu-gou/hub"
+	"github.com/shingetsu-gou/shingetsu-gou/util"
+)
+
+var (
+	reSize   = regexp.MustCompile(`^\d+x\d+$`)
+	reSuffix = regexp.MustCompile(`[^-_.A-Za-z0-9]`)
+)
+
+//isImage returns true if a thumbnail can be made from a file with suffix.
+func isImage(suffix string) bool {
+	switch suffix {
+	case "jpg", "jpeg", "png", "gif":
+		return true
+	}
+	return false
+}
+
+//thumbnailDir returns the directory of thumbnails of the thread datfile.
+func thumbnailDir(datfile string) string {
+	return filepath.Join(cfg.RunDir, "thumbnail", filepath.Base(datfile))
+}
+
+//thumbnailPath returns the path of the thumbnail of the attached file in size.
+func (r *Record) thumbnailPath(size string) string {
+	suffix := reSuffix.ReplaceAllString(r.GetBodyValue("suffix", ""), "")
+	return filepath.Join(thumbnailDir(r.Datfile), r.Idstr()+"."+size+"."+suffix)
+}
+
+//Thumbnail returns the thumbnail of the attached file in size (e.g. 320x240),
+//which is read from the cache on disk, or made and saved to the cache if not cached.
+func (r *Record) Thumbnail(size string) ([]byte, error) {
+	suffix := r.GetBodyValue("suffix", "")
+	if !reSize.MatchString(size) || !isImage(suffix) {
+		return nil, errors.New("cannot make a thumbnail of " + r.Idstr() + " in " + size)
+	}
+	path := r.thumbnailPath(size)
+	if data, err := ioutil.ReadFile(path); err == nil {
+		return data, nil
+	}
+	data, err := r.AttachData()
+	if err != nil {
+		return nil, err
+	}
+	thumb := util.MakeThumbnail(data, suffix, size)
+	if len(thumb) == 0 {
+		return nil, errors.New("failed to make a thumbnail of " + r.Idstr())
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		log.Println(err)
+		return thumb, nil
+	}
+	if err := writeFile(path, thumb); err != nil {
+		log.Println(err)
+	}
+	return thumb, nil
+}
+
+//writeFile writes data to a temporary file unique to the caller and renames it to path,
+//so that concurrent writers and readers never see a partially written file.
+func writeFile(path string, data []byte) error {
+	f, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if errr := f.Close(); err == nil {
+		err = errr
+	}
+	if err == nil {
+		err = os.Chmod(f.Name(), 0644)
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
+
+//removeThumbnails removes cached thumbnails of the record h in all sizes.
+func removeThumbnails(h *Head) {
+	dir := thumbnailDir(h.Datfile)
+	files, err := filepath.Glob(filepath.Join(dir, h.Idstr()+".*"))
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	for _, f := range files {
+		if err := os.Remove(f); err != nil {
+			log.Println(err)
+		}
+	}
+	//removes the directory only if empty.
+	os.Remove(dir)
+}
+
+//RunThumbnailer makes thumbnails in DefaultThumbnailSize of new records with
+//attached images by n workers in background.
+func RunThumbnailer(n int) {
+	if cfg.DefaultThumbnailSize == "" {
+		return
+	}
+	ch := hub.Subscribe(hub.All, 256)
+	for i := 0; i < n; i++ {
+		go func() {
+			for v := range ch {
+				h, ok := v.(*Head)
+				if !ok {
+					continue
+				}
+				r := New(h.Datfile, h.ID, h.Stamp)
+				if err := r.Load(); err != nil {
+					continue
+				}
+				if !r.HasAttach() || !isImage(r.GetBodyValue("suffix", "")) {
+					continue
+				}
+				if _, err := r.Thumbnail(cfg.DefaultThumbnailSize); err != nil {
+					log.Println(err)
+				}
+			}
+		}()
+	}
+}
@@ -0,0 +1,153 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package record
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/shingetsu-gou/shingetsu-gou/cfg"
+	"github.com/shingetsu-gou/shingetsu-gou/db"
+)
+
+//setupDB opens a new db in a temporary directory and returns the directory.
+func setupDB(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "record")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.RunDir = dir
+	db.Setup()
+	return dir
+}
+
+//putImage saves a record with an attached png in thread datfile and returns it.
+func putImage(t *testing.T, datfile string, stamp int64) *Record {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 32, 32))); err != nil {
+		t.Fatal(err)
+	}
+	r := New(datfile, "", 0)
+	r.Build(stamp, map[string]string{
+		"body":   "image",
+		"attach": base64.StdEncoding.EncodeToString(buf.Bytes()),
+		"suffix": "png",
+	}, "")
+	err := db.DB.Update(func(tx *bolt.Tx) error {
+		return r.SyncTX(tx, false)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := New(datfile, r.ID, r.Stamp)
+	if err := l.Load(); err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+func TestThumbnail(t *testing.T) {
+	dir := setupDB(t)
+	defer os.RemoveAll(dir)
+	defer db.DB.Close()
+	r := putImage(t, "thread_a", 1)
+	th, err := r.Thumbnail("8x8")
+	if err != nil || len(th) == 0 {
+		t.Fatal("cannot make a thumbnail", err)
+	}
+	path := r.thumbnailPath("8x8")
+	if _, err := os.Stat(path); err != nil {
+		t.Fatal("thumbnail is not cached", err)
+	}
+	if err := ioutil.WriteFile(path, []byte("cached"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if th, err = r.Thumbnail("8x8"); err != nil || string(th) != "cached" {
+		t.Fatal("thumbnail is not read from the cache", string(th), err)
+	}
+	if _, err = r.Thumbnail("big"); err == nil {
+		t.Fatal("thumbnail in illegal size is made")
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if th, errr := r.Thumbnail("16x16"); errr != nil || len(th) == 0 {
+				t.Error("cannot make a thumbnail concurrently", errr)
+			}
+		}()
+	}
+	wg.Wait()
+	if files, _ := filepath.Glob(r.thumbnailPath("16x16") + "*"); len(files) != 1 {
+		t.Fatal("illegal files after concurrent writes", files)
+	}
+	err = db.DB.Update(func(tx *bolt.Tx) error {
+		d, errr := GetFromDB(tx, r.Head)
+		if errr != nil {
+			return errr
+		}
+		return d.Del(tx)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatal("thumbnail is not removed with the record", err)
+	}
+}
+
+func TestRunThumbnailer(t *testing.T) {
+	dir := setupDB(t)
+	defer os.RemoveAll(dir)
+	defer db.DB.Close()
+	cfg.DefaultThumbnailSize = "8x8"
+	defer func() {
+		cfg.DefaultThumbnailSize = ""
+	}()
+	RunThumbnailer(1)
+	r := putImage(t, "thread_b", 2)
+	path := r.thumbnailPath(cfg.DefaultThumbnailSize)
+	for i := 0; i < 50; i++ {
+		if _, err := os.Stat(path); err == nil {
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Fatal("thumbnail is not made in background")
+}
@@ -0,0 +1,165 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package relay
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/shingetsu-gou/shingetsu-gou/cfg"
+	"github.com/shingetsu-gou/shingetsu-gou/myself"
+	"github.com/shingetsu-gou/shingetsu-gou/node"
+	"github.com/shingetsu-gou/shingetsu-gou/node/manager"
+)
+
+//responseWriter is a http.ResponseWriter which stores the response body.
+type responseWriter struct {
+	header http.Header
+	bytes.Buffer
+}
+
+//Header returns the header map.
+func (r *responseWriter) Header() http.Header {
+	return r.header
+}
+
+//WriteHeader does nothing, because only the body is relayed.
+func (r *responseWriter) WriteHeader(int) {}
+
+//candidates returns nodes which may relay mynode.
+func candidates() node.Slice {
+	if cfg.RelayServer != "" {
+		n, err := node.New(cfg.RelayServer)
+		if err != nil {
+			log.Println(err)
+			return nil
+		}
+		return node.Slice{n}
+	}
+	ns := node.Slice(node.MustNew(manager.GetNodestrSliceInList()))
+	return ns.Extend(node.MustNew(cfg.InitNode.GetData()))
+}
+
+//Run connects to one of relay servers and serves requests relayed by it with h.
+//It reconnects to another server if disconnected.
+func Run(h http.Handler) {
+	const retryInterval = time.Minute
+	id := newID(16)
+	for {
+		for _, n := range candidates() {
+			joined := false
+			for {
+				err := accept(n, id, h, func() {
+					if joined {
+						return
+					}
+					joined = true
+					myself.SetRelayNode(n.Nodestr + "/relay/" + id)
+					log.Println("relayed by", n.Nodestr)
+					go func() {
+						if _, err := n.Join(); err == nil {
+							manager.AppendToList(n)
+						}
+					}()
+				})
+				if err != nil {
+					log.Println(err)
+					break
+				}
+			}
+			if joined {
+				myself.SetRelayNode("")
+			}
+		}
+		<-time.After(retryInterval)
+	}
+}
+
+//accept connects to the relay server n with id, calls fn if accepted,
+//and serves relayed requests with h until the server closes the stream.
+func accept(n *node.Node, id string, h http.Handler, fn func()) error {
+	resp, err := node.PeerClient().Get("http://" + n.Nodestr + "/relay/accept/" + id)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.New(n.Nodestr + " refused to relay: " + resp.Status)
+	}
+	watchdog := time.AfterFunc(2*pingInterval, func() {
+		resp.Body.Close()
+	})
+	defer watchdog.Stop()
+	scanner := bufio.NewScanner(resp.Body)
+	if !scanner.Scan() || scanner.Text() != "RELAY" {
+		return errors.New(n.Nodestr + " is not a relay server")
+	}
+	fn()
+	for scanner.Scan() {
+		watchdog.Reset(2 * pingInterval)
+		f := strings.Fields(scanner.Text())
+		if len(f) != 3 {
+			continue
+		}
+		go respond(n, id, h, f[0], f[1])
+	}
+	return scanner.Err()
+}
+
+//respond serves the relayed request with path by h
+//and posts the response to the relay server n.
+//the remote address told by the relay server is not trusted, so the request is
+//treated as one from n for bans, rate limits and join.
+func respond(n *node.Node, id string, h http.Handler, reqid, path string) {
+	if !reCommand.MatchString(path) || strings.Contains(path, "..") {
+		log.Println(n.Nodestr, "relayed an illegal request", path)
+		return
+	}
+	req, err := http.NewRequest("GET", cfg.ServerURL+"/"+path, nil)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	req.RemoteAddr = n.Nodestr[:strings.Index(n.Nodestr, "/")]
+	w := &responseWriter{header: make(http.Header)}
+	h.ServeHTTP(w, req)
+	resp, err := node.PeerClient().Post("http://"+n.Nodestr+"/relay/response/"+id+"/"+reqid, "text/plain", &w.Buffer)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if err := resp.Body.Close(); err != nil {
+		log.Println(err)
+	}
+}
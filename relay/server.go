@@ -0,0 +1,298 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package relay
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shingetsu-gou/shingetsu-gou/cfg"
+	"github.com/shingetsu-gou/shingetsu-gou/cgi"
+)
+
+const (
+	pingInterval   = 30 * time.Second
+	acceptDuration = 2 * time.Minute // must be shorter than WriteTimeout of the server.
+	requestTimeout = 30 * time.Second
+	clientExpire   = 5 * time.Minute
+	maxResponse    = 32 << 20
+	queueSize      = 16
+)
+
+var (
+	reID = regexp.MustCompile(`^[0-9a-f]{16,64}$`)
+	//reCommand is commands which can be relayed.
+	reCommand = regexp.MustCompile(`^(ping|node|join|bye|have|get|head|summary|update|recent)(/|$)`)
+)
+
+//request is a request relayed to a client.
+type request struct {
+	id     string
+	path   string
+	remote string
+}
+
+//client represents a node behind NAT which is relayed by mynode.
+type client struct {
+	ip       string
+	requests chan *request
+	pending  map[string]chan []byte
+	quit     chan struct{}
+	lastSeen time.Time
+}
+
+var (
+	clients = make(map[string]*client)
+	mutex   sync.Mutex
+)
+
+//Setup setups handlers for relaying nodes behind NAT.
+func Setup(s *cgi.LoggingServeMux) {
+	s.HandleFunc(cfg.ServerURL+"/relay/accept/", doAccept)
+	s.HandleFunc(cfg.ServerURL+"/relay/response/", doResponse)
+	s.RegistCompressHandler(cfg.ServerURL+"/relay/", doRelay)
+}
+
+//remoteIP returns IP address of the remote host of r.
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		log.Println(err)
+		return ""
+	}
+	return host
+}
+
+//newID returns random hex string.
+func newID(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		log.Fatal(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+//removeExpired removes clients which have not connected for clientExpire.
+//mutex must be locked.
+func removeExpired() {
+	for id, c := range clients {
+		if c.quit == nil && time.Since(c.lastSeen) > clientExpire {
+			delete(clients, id)
+		}
+	}
+}
+
+//register registers the client with id and ip and returns the client and a channel
+//which is closed when the client connects again.
+func register(id, ip string) (*client, chan struct{}, int) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	removeExpired()
+	c, exist := clients[id]
+	switch {
+	case !exist && len(clients) >= cfg.MaxRelayClients:
+		return nil, nil, http.StatusServiceUnavailable
+	case !exist:
+		c = &client{
+			ip:       ip,
+			requests: make(chan *request, queueSize),
+			pending:  make(map[string]chan []byte),
+		}
+		clients[id] = c
+	case c.ip != ip:
+		return nil, nil, http.StatusForbidden
+	}
+	if c.quit != nil {
+		close(c.quit)
+	}
+	c.quit = make(chan struct{})
+	return c, c.quit, http.StatusOK
+}
+
+//unregister marks the client disconnected if quit is the current connection.
+func unregister(c *client, quit chan struct{}) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	if c.quit == quit {
+		c.quit = nil
+	}
+	c.lastSeen = time.Now()
+}
+
+//doAccept registers the client with id in url (/relay/accept/<id>) and
+//streams requests to the client line by line as "<request id> <path> <remote addr>".
+//the stream ends in acceptDuration, so the client must reconnect.
+func doAccept(w http.ResponseWriter, r *http.Request) {
+	if cfg.MaxRelayClients <= 0 {
+		http.NotFound(w, r)
+		return
+	}
+	id := strings.TrimPrefix(r.URL.Path, cfg.ServerURL+"/relay/accept/")
+	ip := remoteIP(r)
+	if !reID.MatchString(id) || ip == "" {
+		http.Error(w, "illegal url", http.StatusBadRequest)
+		return
+	}
+	f, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	c, quit, code := register(id, ip)
+	if code != http.StatusOK {
+		http.Error(w, http.StatusText(code), code)
+		return
+	}
+	defer unregister(c, quit)
+	log.Println("relaying", id, "for", ip)
+
+	w.Header().Set("Content-Type", "text/plain")
+	if _, err := fmt.Fprintln(w, "RELAY"); err != nil {
+		return
+	}
+	f.Flush()
+	ping := time.NewTicker(pingInterval)
+	defer ping.Stop()
+	end := time.After(acceptDuration)
+	for {
+		var err error
+		select {
+		case <-r.Context().Done():
+			return
+		case <-quit:
+			return
+		case <-end:
+			return
+		case <-ping.C:
+			_, err = fmt.Fprintln(w, "PING")
+		case req := <-c.requests:
+			_, err = fmt.Fprintln(w, req.id, req.path, req.remote)
+		}
+		if err != nil {
+			log.Println(err)
+			return
+		}
+		f.Flush()
+	}
+}
+
+//doRelay relays the request in url (/relay/<id>/<command>) to the client with id
+//and writes the response from the client.
+func doRelay(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.EscapedPath(), cfg.ServerURL+"/relay/")
+	ss := strings.SplitN(path, "/", 2)
+	if len(ss) != 2 || !reCommand.MatchString(ss[1]) {
+		http.NotFound(w, r)
+		return
+	}
+	mutex.Lock()
+	c, exist := clients[ss[0]]
+	if !exist {
+		mutex.Unlock()
+		http.NotFound(w, r)
+		return
+	}
+	req := &request{
+		id:     newID(8),
+		path:   ss[1],
+		remote: r.RemoteAddr,
+	}
+	ch := make(chan []byte, 1)
+	c.pending[req.id] = ch
+	mutex.Unlock()
+	defer func() {
+		mutex.Lock()
+		delete(c.pending, req.id)
+		mutex.Unlock()
+	}()
+
+	select {
+	case c.requests <- req:
+	default:
+		http.Error(w, "too many requests", http.StatusServiceUnavailable)
+		return
+	}
+	select {
+	case b := <-ch:
+		if _, err := w.Write(b); err != nil {
+			log.Println(err)
+		}
+	case <-time.After(requestTimeout):
+		http.Error(w, "relayed node did not respond", http.StatusGatewayTimeout)
+	case <-r.Context().Done():
+	}
+}
+
+//doResponse passes the body to the request which waits for a response
+//with the id in url (/relay/response/<client id>/<request id>).
+func doResponse(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, cfg.ServerURL+"/relay/response/")
+	ss := strings.Split(path, "/")
+	if len(ss) != 2 {
+		http.NotFound(w, r)
+		return
+	}
+	b, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxResponse))
+	if err != nil {
+		log.Println(err)
+		http.Error(w, "too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	c, exist := clients[ss[0]]
+	if !exist || c.ip != remoteIP(r) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	ch, exist := c.pending[ss[1]]
+	if !exist {
+		http.NotFound(w, r)
+		return
+	}
+	select {
+	case ch <- b:
+		fmt.Fprintln(w, "OK")
+	default:
+		http.Error(w, "already responded", http.StatusConflict)
+	}
+}
@@ -0,0 +1,166 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package search
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/boltdb/bolt"
+	"github.com/shingetsu-gou/shingetsu-gou/db"
+)
+
+/*
+bucket key value
+
+wordIndex word 0x00 thread 0x00 stamp id json(Datfile,Stamp,ID,Pos)
+wordDF word #records which have the word
+*/
+
+//docsKey is the key for # of indexed records in wordDF.
+//words never contain 0x00.
+var docsKey = []byte{0x00}
+
+//posting represents positions of a word in a record.
+type posting struct {
+	Datfile string
+	Stamp   int64
+	ID      string
+	Pos     []int
+}
+
+//addCount adds n to the count of key in wordDF.
+func addCount(tx *bolt.Tx, key []byte, n int) error {
+	var cnt int
+	if _, err := db.Get(tx, "wordDF", key, &cnt); err != nil {
+		cnt = 0
+	}
+	cnt += n
+	if cnt <= 0 {
+		return db.Del(tx, "wordDF", key)
+	}
+	return db.Put(tx, "wordDF", key, cnt)
+}
+
+//getCount returns the count of key in wordDF.
+func getCount(tx *bolt.Tx, key []byte) int {
+	var cnt int
+	if _, err := db.Get(tx, "wordDF", key, &cnt); err != nil {
+		return 0
+	}
+	return cnt
+}
+
+//AddTX adds words in text of the record to the index.
+func AddTX(tx *bolt.Tx, datfile string, stamp int64, id, text string) error {
+	b, err := tx.CreateBucketIfNotExists([]byte("wordIndex"))
+	if err != nil {
+		return err
+	}
+	rkey := db.ToKey(datfile, stamp, id)
+	added := false
+	for w, pos := range group(tokenize(text, false)) {
+		k := append(db.ToKey(w), rkey...)
+		if b.Get(k) != nil {
+			continue
+		}
+		p := posting{
+			Datfile: datfile,
+			Stamp:   stamp,
+			ID:      id,
+			Pos:     pos,
+		}
+		if err := db.Put(tx, "wordIndex", k, &p); err != nil {
+			return err
+		}
+		if err := addCount(tx, []byte(w), 1); err != nil {
+			return err
+		}
+		added = true
+	}
+	if !added {
+		return nil
+	}
+	return addCount(tx, docsKey, 1)
+}
+
+//RemoveTX removes words in text of the record from the index.
+func RemoveTX(tx *bolt.Tx, datfile string, stamp int64, id, text string) error {
+	b := tx.Bucket([]byte("wordIndex"))
+	if b == nil {
+		return nil
+	}
+	rkey := db.ToKey(datfile, stamp, id)
+	removed := false
+	for w := range group(tokenize(text, false)) {
+		k := append(db.ToKey(w), rkey...)
+		if b.Get(k) == nil {
+			continue
+		}
+		if err := b.Delete(k); err != nil {
+			return err
+		}
+		if err := addCount(tx, []byte(w), -1); err != nil {
+			return err
+		}
+		removed = true
+	}
+	if !removed {
+		return nil
+	}
+	return addCount(tx, docsKey, -1)
+}
+
+//lookup returns postings of word.
+//if prefix is true, returns postings of words which start with word.
+func lookup(tx *bolt.Tx, word string, prefix bool) (map[string]*posting, error) {
+	r := make(map[string]*posting)
+	b := tx.Bucket([]byte("wordIndex"))
+	if b == nil {
+		return r, nil
+	}
+	pre := db.ToKey(word)
+	if prefix {
+		pre = []byte(word)
+	}
+	c := b.Cursor()
+	for k, v := c.Seek(pre); bytes.HasPrefix(k, pre); k, v = c.Next() {
+		var p posting
+		if err := json.Unmarshal(v, &p); err != nil {
+			return nil, err
+		}
+		rkey := string(k[bytes.IndexByte(k, 0x00)+1:])
+		if pp, exist := r[rkey]; exist {
+			pp.Pos = append(pp.Pos, p.Pos...)
+			continue
+		}
+		r[rkey] = &p
+	}
+	return r, nil
+}
@@ -0,0 +1,257 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/boltdb/bolt"
+)
+
+//Result represents a record which matches the query.
+type Result struct {
+	Datfile string
+	Stamp   int64
+	ID      string
+	Score   float64
+}
+
+//phrase is a sequence of tokens which must be adjacent.
+type phrase []token
+
+//query is OR of ANDs of phrases.
+type query [][]phrase
+
+//splitQuery splits q by spaces, except in double quotes.
+func splitQuery(q string) []string {
+	var r []string
+	var cur []rune
+	quoted := false
+	for _, c := range q {
+		switch {
+		case c == '"':
+			quoted = !quoted
+			if !quoted {
+				r = append(r, string(cur))
+				cur = cur[:0]
+			}
+		case unicode.IsSpace(c) && !quoted:
+			if len(cur) > 0 {
+				r = append(r, string(cur))
+				cur = cur[:0]
+			}
+		default:
+			cur = append(cur, c)
+		}
+	}
+	if len(cur) > 0 {
+		r = append(r, string(cur))
+	}
+	return r
+}
+
+//parseQuery parses q.
+//words separated by spaces are ANDed, "OR" between words makes OR,
+//and words in double quotes are a phrase.
+func parseQuery(q string) query {
+	var r query
+	var and []phrase
+	for _, w := range splitQuery(q) {
+		if w == "OR" || w == "|" {
+			if len(and) > 0 {
+				r = append(r, and)
+			}
+			and = nil
+			continue
+		}
+		if ts := tokenize(w, true); len(ts) > 0 {
+			and = append(and, phrase(ts))
+		}
+	}
+	if len(and) > 0 {
+		r = append(r, and)
+	}
+	return r
+}
+
+//isPrefixToken returns true if t is one CJK character, which is searched by prefix.
+func isPrefixToken(t token) bool {
+	r, _ := utf8.DecodeRuneInString(t.word)
+	return utf8.RuneCountInString(t.word) == 1 && isCJK(r)
+}
+
+//hit represents a matched record and # of the matches.
+type hit struct {
+	*posting
+	tf int
+}
+
+//matchPhrase returns records which have phrase p, and its idf.
+func matchPhrase(tx *bolt.Tx, p phrase, docs int) (map[string]*hit, float64, error) {
+	postings := make([]map[string]*posting, len(p))
+	for i, t := range p {
+		if !isPrefixToken(t) && getCount(tx, []byte(t.word)) == 0 {
+			return nil, 0, nil
+		}
+		var err error
+		if postings[i], err = lookup(tx, t.word, isPrefixToken(t)); err != nil {
+			return nil, 0, err
+		}
+	}
+	r := make(map[string]*hit)
+	for rkey, first := range postings[0] {
+		pos := make([]map[int]struct{}, len(p))
+		ok := true
+		for i := 1; i < len(p) && ok; i++ {
+			var pp *posting
+			if pp, ok = postings[i][rkey]; !ok {
+				break
+			}
+			pos[i] = make(map[int]struct{})
+			for _, x := range pp.Pos {
+				pos[i][x] = struct{}{}
+			}
+		}
+		if !ok {
+			continue
+		}
+		tf := 0
+		for _, start := range first.Pos {
+			matched := true
+			for i := 1; i < len(p) && matched; i++ {
+				_, matched = pos[i][start+p[i].pos-p[0].pos]
+			}
+			if matched {
+				tf++
+			}
+		}
+		if tf > 0 {
+			r[rkey] = &hit{first, tf}
+		}
+	}
+	idf := math.Log(1 + float64(docs)/float64(len(r)+1))
+	return r, idf, nil
+}
+
+//Search returns records which match the query q, sorted by scores.
+func Search(tx *bolt.Tx, q string) ([]*Result, error) {
+	docs := getCount(tx, docsKey)
+	results := make(map[string]*Result)
+	for _, and := range parseQuery(q) {
+		var scores map[string]*Result
+		for _, p := range and {
+			hits, idf, err := matchPhrase(tx, p, docs)
+			if err != nil {
+				return nil, err
+			}
+			next := make(map[string]*Result)
+			for rkey, h := range hits {
+				score := (1 + math.Log(float64(h.tf))) * idf
+				if scores == nil {
+					next[rkey] = &Result{
+						Datfile: h.Datfile,
+						Stamp:   h.Stamp,
+						ID:      h.ID,
+						Score:   score,
+					}
+					continue
+				}
+				if r, exist := scores[rkey]; exist {
+					r.Score += score
+					next[rkey] = r
+				}
+			}
+			scores = next
+			if len(scores) == 0 {
+				break
+			}
+		}
+		for rkey, r := range scores {
+			if rr, exist := results[rkey]; exist {
+				rr.Score += r.Score
+				continue
+			}
+			results[rkey] = r
+		}
+	}
+	rs := make([]*Result, 0, len(results))
+	for _, r := range results {
+		rs = append(rs, r)
+	}
+	sort.Sort(byScore(rs))
+	return rs, nil
+}
+
+//byScore is for sorting results by score and stamp.
+type byScore []*Result
+
+//Len returns size of results.
+func (b byScore) Len() int {
+	return len(b)
+}
+
+//Swap swaps results.
+func (b byScore) Swap(i, j int) {
+	b[i], b[j] = b[j], b[i]
+}
+
+//Less returns true if results[i] has a higher score or is newer than results[j].
+func (b byScore) Less(i, j int) bool {
+	if b[i].Score != b[j].Score {
+		return b[i].Score > b[j].Score
+	}
+	return b[i].Stamp > b[j].Stamp
+}
+
+//Match returns true if text contains all words in one of OR groups of query q
+//without using the index. used for short texts like titles.
+func Match(text, q string) bool {
+	text = normalize(text)
+	and := true
+	n := 0
+	for _, w := range append(splitQuery(q), "OR") {
+		if w == "OR" || w == "|" {
+			if and && n > 0 {
+				return true
+			}
+			and = true
+			n = 0
+			continue
+		}
+		n++
+		if !strings.Contains(text, normalize(w)) {
+			and = false
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package search
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boltdb/bolt"
+)
+
+func TestTokenize(t *testing.T) {
+	ts := tokenize("Ｇｏｕは東京都 iPhone", false)
+	words := []string{"gou", "は東", "東京", "京都", "都", "iphone"}
+	if len(ts) != len(words) {
+		t.Fatal("illegal tokens", ts)
+	}
+	for i, w := range words {
+		if ts[i].word != w {
+			t.Fatal("illegal token", i, ts[i], w)
+		}
+	}
+	if q := parseQuery(`東京都 "foo bar" OR baz`); len(q) != 2 || len(q[0]) != 2 || len(q[1]) != 1 {
+		t.Fatal("illegal query", q)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "search")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	d, err := bolt.Open(filepath.Join(dir, "test.db"), 0644, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	err = d.Update(func(tx *bolt.Tx) error {
+		if errr := AddTX(tx, "thread_a", 1, "id1", "東京都に住んでいます"); errr != nil {
+			return errr
+		}
+		if errr := AddTX(tx, "thread_a", 2, "id2", "京都と東京 foo bar"); errr != nil {
+			return errr
+		}
+		return AddTX(tx, "thread_b", 3, "id3", "bar foo")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := map[string][]string{
+		"東京都":        {"id1"},
+		"京都":         {"id1", "id2"},
+		"都":          {"id1", "id2"},
+		`"foo bar"`:  {"id2"},
+		"foo bar":    {"id2", "id3"},
+		"住んで OR bar": {"id1", "id2", "id3"},
+		"大阪":         nil,
+	}
+	err = d.View(func(tx *bolt.Tx) error {
+		for q, ids := range cases {
+			rs, errr := Search(tx, q)
+			if errr != nil {
+				return errr
+			}
+			if len(rs) != len(ids) {
+				t.Fatal("illegal results for", q, len(rs))
+			}
+			for _, id := range ids {
+				found := false
+				for _, r := range rs {
+					found = found || r.ID == id
+				}
+				if !found {
+					t.Fatal(id, "not found for", q)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = d.Update(func(tx *bolt.Tx) error {
+		return RemoveTX(tx, "thread_a", 1, "id1", "東京都に住んでいます")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = d.View(func(tx *bolt.Tx) error {
+		rs, errr := Search(tx, "東京都")
+		if len(rs) != 0 {
+			t.Fatal("removed record is found")
+		}
+		if n := getCount(tx, docsKey); n != 2 {
+			t.Fatal("illegal # of docs", n)
+		}
+		return errr
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,114 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+//maxWordLen is max length of one word. longer words are truncated.
+const maxWordLen = 32
+
+//token represents one word in the text and its position.
+type token struct {
+	word string
+	pos  int
+}
+
+//isCJK returns true if r is a character which is not separated by spaces.
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) || r == 'ー' || r == '々'
+}
+
+//normalize folds width of characters and lowers cases.
+func normalize(text string) string {
+	return strings.ToLower(width.Fold.String(text))
+}
+
+//tokenize splits text into words.
+//alphanumerics are split by other characters, and CJK sequences are split into bigrams.
+//the last character of a CJK sequence is also added as a unigram to be matched by one character query,
+//except in query.
+func tokenize(text string, query bool) []token {
+	var ts []token
+	var word, cjk []rune
+	pos := 0
+	flushWord := func() {
+		if len(word) == 0 {
+			return
+		}
+		if len(word) > maxWordLen {
+			word = word[:maxWordLen]
+		}
+		ts = append(ts, token{string(word), pos})
+		pos++
+		word = word[:0]
+	}
+	flushCJK := func() {
+		if len(cjk) == 0 {
+			return
+		}
+		for i := 0; i+1 < len(cjk); i++ {
+			ts = append(ts, token{string(cjk[i : i+2]), pos + i})
+		}
+		if len(cjk) == 1 || !query {
+			ts = append(ts, token{string(cjk[len(cjk)-1]), pos + len(cjk) - 1})
+		}
+		pos += len(cjk)
+		cjk = cjk[:0]
+	}
+	for _, r := range normalize(text) {
+		switch {
+		case isCJK(r):
+			flushWord()
+			cjk = append(cjk, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushCJK()
+			word = append(word, r)
+		default:
+			flushWord()
+			flushCJK()
+		}
+	}
+	flushWord()
+	flushCJK()
+	return ts
+}
+
+//group returns map of word and its positions.
+func group(ts []token) map[string][]int {
+	m := make(map[string][]int)
+	for _, t := range ts {
+		m[t.word] = append(m[t.word], t.pos)
+	}
+	return m
+}
@@ -31,6 +31,7 @@
 package shingetsu
 
 import (
+	"context"
 	"log"
 	"time"
 
@@ -43,6 +44,7 @@ import (
 
 var listener net.Listener
 var ch chan error
+var cancel context.CancelFunc
 
 //ExpandFiles expands files in files dir.
 func ExpandFiles(rpath string,location string,timeoffset int) {
@@ -64,12 +66,15 @@ func Port() int {
 //You must call ExpandFiles beforehand.
 func Run() {
 	db.Setup()
-	listener, ch = gou.StartDaemon()
+	var ctx context.Context
+	ctx, cancel = context.WithCancel(context.Background())
+	listener, ch = gou.StartDaemon(ctx)
 }
 
 //Stop stops the http server.
 func Stop() {
 	if listener != nil {
+		cancel()
 		listener.Close()
 		db.DB.Close()
 		log.Println(<-ch)
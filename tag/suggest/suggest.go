@@ -90,6 +90,14 @@ func AddString(tx *bolt.Tx, datfile string, vals []string) {
 	}
 }
 
+//RemoveTX removes all suggested tags of datfile.
+func RemoveTX(tx *bolt.Tx, datfile string) error {
+	if tx.Bucket([]byte("sugtag")) == nil {
+		return nil
+	}
+	return db.Del(tx, "sugtag", []byte(datfile))
+}
+
 //HasTagstr return true if one of tags has tagstr
 func HasTagstr(datfile string, tagstr string) bool {
 	var r bool
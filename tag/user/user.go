@@ -33,6 +33,7 @@ import (
 
 	"github.com/boltdb/bolt"
 	"github.com/shingetsu-gou/shingetsu-gou/db"
+	"github.com/shingetsu-gou/shingetsu-gou/record"
 	"github.com/shingetsu-gou/shingetsu-gou/tag"
 )
 
@@ -128,7 +129,7 @@ func AddTX(tx *bolt.Tx, thread string, tag []string) error {
 			return err
 		}
 	}
-	return nil
+	return record.TouchMetaTX(tx, thread)
 }
 
 //Set remove all tags and saves tag strings.
@@ -58,81 +58,36 @@ func NewCache(datfile string) *Cache {
 
 //Stamp returns latest stampl of records in the cache.
 func (c *Cache) Stamp() int64 {
-	var r []*record.DB
-	err := db.DB.View(func(tx *bolt.Tx) error {
-		var err error
-		r, err = record.GetFromDBs(tx, c.Datfile)
-		return err
-	})
-	if err != nil {
-		log.Print(err)
-		return 0
-	}
-	if len(r) == 0 {
-		return 0
-	}
-	var stamp int64
-	for i := len(r) - 1; i >= 0; i-- {
-		if !r[i].Deleted {
-			stamp = r[i].Stamp
-			break
-		}
-	}
-	return stamp
+	return record.GetMeta(c.Datfile).Stamp
 }
 
 //Len returns # of records in the cache.
 func (c *Cache) Len(kind int) int {
-	m, err := record.FromRecordDB(c.Datfile, kind)
-	if err != nil {
-		log.Print(err)
-		return 0
+	m := record.GetMeta(c.Datfile)
+	switch kind {
+	case record.Alive:
+		return m.Alive
+	case record.Removed:
+		return m.Removed
+	case record.All:
+		return m.Alive + m.Removed
 	}
-	return len(m)
+	return 0
 }
 
-//Velocity returns number of records in one days in the cache.
+//Velocity returns number of records in 7 days in the cache.
 func (c *Cache) Velocity() int {
-	var r []*record.DB
-	err := db.DB.View(func(tx *bolt.Tx) error {
-		var err error
-		r, err = record.GetFromDBs(tx, c.Datfile)
-		return err
-	})
-	if err != nil {
-		log.Print(err)
-		return 0
-	}
-	cnt := 0
-	t := int64(time.Now().Add(-7 * 24 * time.Hour).Second())
-	for _, rr := range r {
-		if rr.Stamp > t {
-			cnt++
-		}
-	}
-	return cnt
+	return record.GetMeta(c.Datfile).Velocity()
 }
 
 //Size returns sum of body char length of records in the cache.
 func (c *Cache) Size() int64 {
-	if c.Len(record.All) == 0 {
-		return 0
-	}
-	var r []*record.DB
-	err := db.DB.View(func(tx *bolt.Tx) error {
-		var err error
-		r, err = record.GetFromDBs(tx, c.Datfile)
-		return err
-	})
-	if err != nil {
-		log.Print(err)
-		return 0
-	}
-	var cnt int64
-	for _, rr := range r {
-		cnt += int64(len(rr.Body))
-	}
-	return cnt
+	return record.GetMeta(c.Datfile).Size
+}
+
+//Modified returns the time when the info of the cache was changed last, or 0 if unknown.
+func (c *Cache) Modified() int64 {
+	return record.GetMeta(c.Datfile).Modified(time.Now().Unix())
 }
 
 //LoadRecords loads and returns record maps from the disk..
@@ -145,18 +100,25 @@ func (c *Cache) LoadRecords(kind int) record.Map {
 	return m
 }
 
-//subscribe add the thread to thread db.
-func (c *Cache) subscribe(tx *bolt.Tx) {
+//SubscribeTX add the thread to thread db.
+//the thread is not regarded as evicted any more.
+func (c *Cache) SubscribeTX(tx *bolt.Tx) {
 	err := db.Put(tx, "thread", []byte(c.Datfile), []byte(""))
 	if err != nil {
 		log.Print(err)
 	}
+	if tx.Bucket([]byte("evicted")) == nil {
+		return
+	}
+	if err := db.Del(tx, "evicted", []byte(c.Datfile)); err != nil {
+		log.Print(err)
+	}
 }
 
 //Subscribe add the thread to thread db.
 func (c *Cache) Subscribe() {
 	err := db.DB.Update(func(tx *bolt.Tx) error {
-		c.subscribe(tx)
+		c.SubscribeTX(tx)
 		return nil
 	})
 	if err != nil {
@@ -167,7 +129,9 @@ func (c *Cache) Subscribe() {
 //CheckData makes a record from res and checks its records meets condisions of args.
 //adds the rec to cache if meets conditions.
 //if spam or big data, remove the rec from disk.
-//returns spam/getting error.
+//if signature is forged, saves the rec as forged.
+//if the rec is a deletion notice from the author or moderators, removes the target.
+//returns spam/forged/getting error.
 func (c *Cache) CheckData(tx *bolt.Tx, res string, stamp int64,
 	id string, begin, end int64) error {
 	r := record.New(c.Datfile, "", 0)
@@ -196,44 +160,102 @@ func (c *Cache) CheckData(tx *bolt.Tx, res string, stamp int64,
 	if deleted {
 		return cfg.ErrSpam
 	}
+	if r.CheckSign() == record.SignNG {
+		log.Printf("warning:%s/%s:forged signature", r.Datfile, r.Idstr())
+		return cfg.ErrForged
+	}
+	if err := r.RemoveTargetTX(tx); err != nil {
+		log.Println(err)
+	}
 	return nil
 }
 
 //Remove Remove all files and dirs of cache.
 func (c *Cache) Remove() {
 	err := db.DB.Update(func(tx *bolt.Tx) error {
-		r, err := record.GetFromDBs(tx, c.Datfile)
-		if err != nil {
+		return c.RemoveTX(tx)
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+//RemoveTX removes all records of the cache and the cache itself from db.
+func (c *Cache) RemoveTX(tx *bolt.Tx) error {
+	r, err := record.GetFromDBs(tx, c.Datfile)
+	if err != nil {
+		return err
+	}
+	for _, rr := range r {
+		if err := rr.Del(tx); err != nil {
 			return err
 		}
-		for _, rr := range r {
-			rr.Del(tx)
+	}
+	if err := record.RemovePendingsTX(tx, c.Datfile); err != nil {
+		return err
+	}
+	if tx.Bucket([]byte("threadAccess")) != nil {
+		if err := db.Del(tx, "threadAccess", []byte(c.Datfile)); err != nil {
+			return err
 		}
-		return db.Del(tx, "thread", []byte(c.Datfile))
+	}
+	return db.Del(tx, "thread", []byte(c.Datfile))
+}
+
+//EvictTX removes the cache and marks it as evicted,
+//so that it is not subscribed again automatically.
+func (c *Cache) EvictTX(tx *bolt.Tx) error {
+	if err := c.RemoveTX(tx); err != nil {
+		return err
+	}
+	return db.Put(tx, "evicted", []byte(c.Datfile), time.Now().Unix())
+}
+
+//EvictedTX returns true if the cache was evicted and is not subscribed again.
+func (c *Cache) EvictedTX(tx *bolt.Tx) bool {
+	r, err := db.HasKey(tx, "evicted", []byte(c.Datfile))
+	return err == nil && r
+}
+
+//Evicted returns true if the cache was evicted and is not subscribed again.
+func (c *Cache) Evicted() bool {
+	var r bool
+	err := db.DB.View(func(tx *bolt.Tx) error {
+		r = c.EvictedTX(tx)
+		return nil
 	})
 	if err != nil {
 		log.Println(err)
 	}
+	return r
 }
 
-//HasRecord return true if  cache has more than one records or removed records.
-func (c *Cache) HasRecord() bool {
-	var r []*record.DB
-	err := db.DB.View(func(tx *bolt.Tx) error {
-		var err error
-		r, err = record.GetFromDBs(tx, c.Datfile)
-		return err
+//Touch records that the cache is viewed now.
+func (c *Cache) Touch() {
+	err := db.DB.Batch(func(tx *bolt.Tx) error {
+		return db.Put(tx, "threadAccess", []byte(c.Datfile), time.Now().Unix())
 	})
 	if err != nil {
-		log.Print(err)
-		return false
+		log.Println(err)
 	}
-	for _, rr := range r {
-		if !rr.Deleted {
-			return true
-		}
+}
+
+//Accessed returns the last time when the cache was viewed or updated.
+func (c *Cache) Accessed() int64 {
+	var a int64
+	err := db.DB.View(func(tx *bolt.Tx) error {
+		_, err := db.Get(tx, "threadAccess", []byte(c.Datfile), &a)
+		return err
+	})
+	if s := c.Stamp(); err != nil || s > a {
+		return s
 	}
-	return false
+	return a
+}
+
+//HasRecord return true if  cache has more than one records or removed records.
+func (c *Cache) HasRecord() bool {
+	return record.GetMeta(c.Datfile).Alive > 0
 }
 
 //Exists return true is datapath exists.
@@ -286,8 +308,8 @@ func CreateAllCachedirs() {
 	err := db.DB.Update(func(tx *bolt.Tx) error {
 		for _, rh := range recs {
 			ca := NewCache(rh.Datfile)
-			if !ca.Exists() {
-				ca.subscribe(tx)
+			if !ca.Exists() && !ca.EvictedTX(tx) {
+				ca.SubscribeTX(tx)
 			}
 		}
 		return nil
@@ -30,14 +30,11 @@ package thread
 
 import (
 	"log"
-	"time"
-
-	"regexp"
 
 	"github.com/boltdb/bolt"
-	"github.com/shingetsu-gou/shingetsu-gou/cfg"
 	"github.com/shingetsu-gou/shingetsu-gou/db"
 	"github.com/shingetsu-gou/shingetsu-gou/record"
+	"github.com/shingetsu-gou/shingetsu-gou/search"
 )
 
 //AllCaches returns all  thread names
@@ -64,7 +61,7 @@ func Len() int {
 	var r []string
 	err := db.DB.View(func(tx *bolt.Tx) error {
 		var err error
-		r, err = db.GetPrefixs(tx, "record")
+		r, err = db.KeyStrings(tx, "threadMeta")
 		return err
 	})
 	if err != nil {
@@ -75,68 +72,45 @@ func Len() int {
 	return len(r)
 }
 
-//Search reloads records in Caches in cachelist
-//and returns slice of cache which matches query.
-func Search(q string) Caches {
-	reg, err := regexp.Compile(q)
-	if err != nil {
-		log.Println(err)
-		return nil
-	}
-	var cnt []string
-	err = db.DB.View(func(tx *bolt.Tx) error {
-		return record.ForEach(tx,
-			func(d *record.DB) error {
-				if reg.Match([]byte(d.Body)) {
-					cnt = append(cnt, d.Datfile)
-				}
-				return nil
-			})
+//SearchRecords returns records which match query q in the search index,
+//sorted by scores.
+func SearchRecords(q string) []*search.Result {
+	var r []*search.Result
+	err := db.DB.View(func(tx *bolt.Tx) error {
+		var err error
+		r, err = search.Search(tx, q)
+		return err
 	})
 	if err != nil {
 		log.Println(err)
 	}
-	result := make([]*Cache, len(cnt))
+	return r
+}
 
-	for i, rr := range cnt {
-		result[i] = NewCache(rr)
+//Search returns slice of cache which has records matching query q,
+//sorted by the best score of records.
+func Search(q string) Caches {
+	var result Caches
+	done := make(map[string]struct{})
+	for _, r := range SearchRecords(q) {
+		if _, exist := done[r.Datfile]; exist {
+			continue
+		}
+		done[r.Datfile] = struct{}{}
+		result = append(result, NewCache(r.Datfile))
 	}
 	return result
 }
 
-//CleanRecords remove old or duplicates records for each Caches.
+//CleanRecords removes records older than save_record in each thread,
+//keeping the newest save_size records regardless of age.
 func CleanRecords() {
-	if cfg.SaveRecord <= 0 {
-		return
-	}
-	err := db.DB.Update(func(tx *bolt.Tx) error {
-		return record.ForEach(tx, func(rec *record.DB) error {
-			if rec.Head.Stamp < time.Now().Unix()-cfg.SaveRecord {
-				rec.Del(tx)
-			}
-			return nil
-		})
-	})
-	if err != nil {
-		log.Println(err)
-	}
+	removeExpired(true, false)
 }
 
-//RemoveRemoved removes files in removed dir if old.
+//RemoveRemoved removes deleted records if older than save_removed,
+//and deletion notices whose targets are not received within save_removed.
 func RemoveRemoved() {
-	if cfg.SaveRemoved <= 0 {
-		return
-	}
-	err := db.DB.Update(func(tx *bolt.Tx) error {
-		return record.ForEach(tx,
-			func(rec *record.DB) error {
-				if rec.Deleted && rec.Head.Stamp < time.Now().Unix()-cfg.SaveRemoved {
-					rec.Del(tx)
-				}
-				return nil
-			})
-	})
-	if err != nil {
-		log.Println(err)
-	}
+	removeExpired(false, true)
+	record.RemoveExpiredPendings()
 }
@@ -29,9 +29,14 @@
 package download
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"math"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -182,21 +187,65 @@ func (dm *Manager) Finished(n *node.Node, success bool) {
 	dm.checkFinished()
 }
 
-//headWithRange checks node n has records with range and adds records which should be downloaded to downloadmanager.
-func headWithRange(n *node.Node, c *thread.Cache, dm *Manager) bool {
-	begin := time.Now().Unix() - cfg.GetRange
-	if rec, err := recentlist.Newest(c.Datfile); err == nil {
-		begin = rec.Stamp - cfg.GetRange
+//errNoSummary is returned if the node doesn't support /summary.
+var errNoSummary = errors.New("summary is not supported")
+
+//reconcile compares summaries of records in begin~end in node n with ones in the cache,
+//and returns heads of records in buckets which differ and # of records n has.
+//buckets with many records are compared again by splitting into smaller buckets.
+func reconcile(ctx context.Context, n *node.Node, s *thread.Summarizer, datfile string, begin, end int64, depth int) ([]string, int, error) {
+	const (
+		maxHeads = 64 // gets heads instead of summaries if # of records in a bucket is less than this.
+		maxDepth = 3
+	)
+	res, err := n.TalkContext(ctx, fmt.Sprintf("/summary/%s/%d-%d", datfile, begin, end), nil)
+	if err != nil {
+		return nil, 0, err
 	}
-	if cfg.GetRange == 0 || begin < 0 {
-		begin = 0
+	if len(res) == 0 {
+		return nil, 0, errNoSummary
+	}
+	bs := make([]*thread.Bucket, len(res))
+	total := 0
+	for i, line := range res {
+		if bs[i], err = thread.ParseBucket(line); err != nil {
+			return nil, 0, errNoSummary
+		}
+		total += bs[i].Count
+	}
+	var heads []string
+	for _, b := range bs {
+		if b.Count == 0 || s.Bucket(b.From, b.To).Hash == b.Hash {
+			continue
+		}
+		var r []string
+		if b.Count <= maxHeads || depth >= maxDepth || b.From == b.To {
+			r, err = n.TalkContext(ctx, fmt.Sprintf("/head/%s/%d-%d", datfile, b.From, b.To), nil)
+		} else {
+			r, _, err = reconcile(ctx, n, s, datfile, b.From, b.To, depth+1)
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		heads = append(heads, r...)
+	}
+	return heads, total, nil
+}
+
+//headWithRange checks node n has records newer than begin and adds records which should be downloaded to downloadmanager.
+//it finds differences by /summary, or gets all heads by /head if n doesn't support it.
+func headWithRange(ctx context.Context, n *node.Node, c *thread.Cache, dm *Manager, begin int64) bool {
+	end := int64(math.MaxInt32)
+	res, total, err := reconcile(ctx, n, c.NewSummarizer(begin, end), c.Datfile, begin, end, 0)
+	if err == errNoSummary {
+		res, err = n.TalkContext(ctx, fmt.Sprintf("/head/%s/%d-", c.Datfile, begin), nil)
+		total = len(res)
 	}
-	res, err := n.Talk(fmt.Sprintf("/head/%s/%d-", c.Datfile, begin), nil)
 	if err != nil {
 		return false
 	}
-	if len(res) == 0 {
-		ress, errr := n.Talk(fmt.Sprintf("/have/%s", c.Datfile), nil)
+	if total == 0 {
+		ress, errr := n.TalkContext(ctx, fmt.Sprintf("/have/%s", c.Datfile), nil)
 		if errr != nil || len(ress) == 0 || ress[0] != "YES" {
 			manager.RemoveFromTable(c.Datfile, n)
 		} else {
@@ -205,6 +254,9 @@ func headWithRange(n *node.Node, c *thread.Cache, dm *Manager) bool {
 		return false
 	}
 	manager.AppendToTable(c.Datfile, n)
+	if len(res) == 0 {
+		return false
+	}
 	dm.Set(res, n)
 	return true
 }
@@ -212,7 +264,7 @@ func headWithRange(n *node.Node, c *thread.Cache, dm *Manager) bool {
 //getWithRange gets records with range using node n and adds to cache after checking them.
 //if no records exist in cache, uses head
 //return true if gotten records>0
-func getWithRange(n *node.Node, c *thread.Cache, dm *Manager) bool {
+func getWithRange(ctx context.Context, n *node.Node, c *thread.Cache, dm *Manager) bool {
 	got := false
 	for {
 		from, to := dm.Get(n)
@@ -221,7 +273,7 @@ func getWithRange(n *node.Node, c *thread.Cache, dm *Manager) bool {
 		}
 
 		var okcount int
-		ress, err := n.Talk(fmt.Sprintf("/get/%s/%d-%d", c.Datfile, from, to), nil)
+		ress, err := n.TalkContext(ctx, fmt.Sprintf("/get/%s/%d-%d", c.Datfile, from, to), nil)
 		if err != nil {
 			dm.Finished(n, false)
 			return false
@@ -245,10 +297,20 @@ func getWithRange(n *node.Node, c *thread.Cache, dm *Manager) bool {
 }
 
 //GetCache checks  nodes in lookuptable have the cache.
-//if found gets records.
-func GetCache(background bool, c *thread.Cache) bool {
+//if found gets records. downloading is stopped when ctx is done.
+func GetCache(ctx context.Context, background bool, c *thread.Cache) bool {
+	return getCache(ctx, background, c, nil, beginStamp(c))
+}
+
+//getCache gets records newer than begin in the cache from nodes in lookuptable except exclude.
+func getCache(ctx context.Context, background bool, c *thread.Cache, exclude node.Slice, begin int64) bool {
 	const searchDepth = 100 // Search node size
-	ns := manager.NodesForGet(c.Datfile, searchDepth)
+	var ns node.Slice
+	for _, n := range manager.NodesForGet(c.Datfile, searchDepth) {
+		if !exclude.Has(n) {
+			ns = append(ns, n)
+		}
+	}
 	found := false
 	var wg sync.WaitGroup
 	var mutex sync.RWMutex
@@ -257,10 +319,10 @@ func GetCache(background bool, c *thread.Cache) bool {
 		wg.Add(1)
 		go func(n *node.Node) {
 			defer wg.Done()
-			if !headWithRange(n, c, dm) {
+			if !headWithRange(ctx, n, c, dm, begin) {
 				return
 			}
-			if getWithRange(n, c, dm) {
+			if getWithRange(ctx, n, c, dm) {
 				mutex.Lock()
 				found = true
 				mutex.Unlock()
@@ -303,11 +365,117 @@ func bg(c *thread.Cache, wg *sync.WaitGroup) {
 	}
 }
 
-//Getall reload all records in cache in cachelist from network.
-func Getall() {
-	for _, ca := range thread.AllCaches() {
+//beginStamp returns the stamp from which records in c should be gotten.
+func beginStamp(c *thread.Cache) int64 {
+	begin := time.Now().Unix() - cfg.GetRange
+	if rec, err := recentlist.Newest(c.Datfile); err == nil {
+		begin = rec.Stamp - cfg.GetRange
+	}
+	if cfg.GetRange == 0 || begin < 0 {
+		begin = 0
+	}
+	return begin
+}
+
+//syncBegin returns the stamp from which records in c should be synced by cron,
+//i.e. beginStamp limited to sync_range.
+func syncBegin(c *thread.Cache) int64 {
+	begin := beginStamp(c)
+	if cfg.SyncRange <= 0 {
+		return begin
+	}
+	if b := time.Now().Unix() - cfg.SyncRange; b > begin {
+		begin = b
+	}
+	return begin
+}
+
+//bulkGet gets records newer than the latest one in each cache in cs from n by /bulk.
+//returns false if failed.
+func bulkGet(ctx context.Context, n *node.Node, cs thread.Caches) bool {
+	const (
+		threadsPerRequest = 500
+		recordsPerTX      = 100
+	)
+	for len(cs) > 0 {
+		l := len(cs)
+		if l > threadsPerRequest {
+			l = threadsPerRequest
+		}
+		var body bytes.Buffer
+		begins := make(map[string]int64)
+		for _, c := range cs[:l] {
+			begin := syncBegin(c)
+			if s := c.Stamp(); s >= begin {
+				begin = s + 1
+			}
+			begins[c.Datfile] = begin
+			fmt.Fprintf(&body, "%s %d-\n", c.Datfile, begin)
+		}
+		cs = cs[l:]
+		var lines []string
+		tabled := make(map[string]struct{})
+		save := func() {
+			err := db.DB.Update(func(tx *bolt.Tx) error {
+				for _, line := range lines {
+					ss := strings.SplitN(line, "<>", 2)
+					begin, exist := begins[ss[0]]
+					if len(ss) != 2 || !exist {
+						continue
+					}
+					if err := thread.NewCache(ss[0]).CheckData(tx, ss[1], -1, "", begin, math.MaxInt32); err != nil {
+						log.Println(err)
+					}
+					if _, exist := tabled[ss[0]]; !exist {
+						manager.AppendToTableTX(tx, ss[0], n)
+						tabled[ss[0]] = struct{}{}
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				log.Println(err)
+			}
+			lines = lines[:0]
+		}
+		_, err := n.PostContext(ctx, "/bulk", &body, func(line string) error {
+			if lines = append(lines, line); len(lines) >= recordsPerTX {
+				save()
+			}
+			return nil
+		})
+		save()
+		if err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+//Getall reload all records in cache in cachelist from network until ctx is done.
+//new records are gotten by /bulk at once from nodes which support it first,
+//and then records in each thread are reconciled with other nodes.
+//records older than the latest one which only bulk nodes have are reconciled
+//when the thread is read.
+func Getall(ctx context.Context) {
+	const bulkNodes = 5
+	cs := thread.AllCaches()
+	var bulk node.Slice
+	for _, n := range manager.Random(nil, 0) {
+		if len(bulk) >= bulkNodes || ctx.Err() != nil {
+			break
+		}
+		if n.Capable("bulk") && bulkGet(ctx, n, cs) {
+			log.Println("got new records of all threads from", n.Nodestr)
+			bulk = append(bulk, n)
+		}
+	}
+	for _, ca := range cs {
+		if ctx.Err() != nil {
+			return
+		}
 		log.Println(ca.Datfile, "is downloading...")
-		GetCache(false, ca)
+		getCache(ctx, false, ca, bulk, syncBegin(ca))
 		log.Println(ca.Datfile, "end")
 	}
 }
@@ -0,0 +1,156 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package thread
+
+import (
+	"log"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/shingetsu-gou/shingetsu-gou/cfg"
+	"github.com/shingetsu-gou/shingetsu-gou/db"
+	"github.com/shingetsu-gou/shingetsu-gou/record"
+)
+
+//Retention represents records in a thread which would be removed by a retention pass.
+type Retention struct {
+	Datfile string
+	Records int //# of records in the thread.
+	Old     int //# of old records to be removed.
+	Removed int //# of removed records to be removed.
+}
+
+//expired returns old and removed records in a thread which should be removed.
+//recs must be sorted by stamp.
+//old ones are alive records older than save_record except the newest save_size records,
+//and removed ones are deleted records older than save_removed.
+func expired(recs []*record.DB, now int64) ([]*record.DB, []*record.DB) {
+	var alive, old, removed []*record.DB
+	for _, rec := range recs {
+		switch {
+		case !rec.Deleted:
+			alive = append(alive, rec)
+		case cfg.SaveRemoved > 0 && rec.Stamp < now-cfg.SaveRemoved:
+			removed = append(removed, rec)
+		}
+	}
+	if cfg.SaveRecord <= 0 {
+		return nil, removed
+	}
+	keep := cfg.SaveSize
+	if keep < 0 {
+		keep = 0
+	}
+	for i := 0; i < len(alive)-keep && alive[i].Stamp < now-cfg.SaveRecord; i++ {
+		old = append(old, alive[i])
+	}
+	return old, removed
+}
+
+//forEachThreadTX calls eachDo with all records in each thread sorted by stamp.
+func forEachThreadTX(tx *bolt.Tx, eachDo func([]*record.DB) error) error {
+	var recs []*record.DB
+	err := record.ForEach(tx, func(rec *record.DB) error {
+		if len(recs) > 0 && recs[0].Datfile != rec.Datfile {
+			if err := eachDo(recs); err != nil {
+				return err
+			}
+			recs = nil
+		}
+		recs = append(recs, rec)
+		return nil
+	})
+	if err != nil || len(recs) == 0 {
+		return err
+	}
+	return eachDo(recs)
+}
+
+//Retentions returns threads which have records to be removed by a retention pass,
+//without removing them.
+func Retentions() []*Retention {
+	var r []*Retention
+	now := time.Now().Unix()
+	err := db.DB.View(func(tx *bolt.Tx) error {
+		return forEachThreadTX(tx, func(recs []*record.DB) error {
+			old, removed := expired(recs, now)
+			if len(old)+len(removed) == 0 {
+				return nil
+			}
+			r = append(r, &Retention{
+				Datfile: recs[0].Datfile,
+				Records: len(recs),
+				Old:     len(old),
+				Removed: len(removed),
+			})
+			return nil
+		})
+	})
+	if err != nil {
+		log.Println(err)
+	}
+	return r
+}
+
+//removeExpired removes old records if old and removed records if removed
+//according to retention settings.
+//records are collected first and then removed in the same transaction,
+//because the cursor cannot be used while deleting.
+func removeExpired(old, removed bool) {
+	now := time.Now().Unix()
+	var dels []*record.DB
+	err := db.DB.Update(func(tx *bolt.Tx) error {
+		err := forEachThreadTX(tx, func(recs []*record.DB) error {
+			o, r := expired(recs, now)
+			if old {
+				dels = append(dels, o...)
+			}
+			if removed {
+				dels = append(dels, r...)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, rec := range dels {
+			if err := rec.Del(tx); err != nil {
+				log.Println(rec.Idstr(), err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if len(dels) > 0 {
+		log.Println(len(dels), "records were removed by retention settings")
+	}
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package thread
+
+import (
+	"testing"
+
+	"github.com/shingetsu-gou/shingetsu-gou/cfg"
+	"github.com/shingetsu-gou/shingetsu-gou/record"
+)
+
+func TestExpired(t *testing.T) {
+	cfg.SaveRecord = 100
+	cfg.SaveRemoved = 200
+	cfg.SaveSize = 3
+	var recs []*record.DB
+	for i := 0; i < 10; i++ {
+		recs = append(recs, &record.DB{
+			Head:    &record.Head{Stamp: int64(i * 100)},
+			Deleted: i%4 == 0,
+		})
+	}
+	old, removed := expired(recs, 1000)
+	if len(old) != 4 {
+		t.Error("illegal # of old records", len(old))
+	}
+	for _, r := range old {
+		if r.Deleted || r.Stamp >= 900 {
+			t.Error("illegal old record", r.Stamp)
+		}
+	}
+	if len(removed) != 2 || removed[0].Stamp != 0 || removed[1].Stamp != 400 {
+		t.Error("illegal removed records", removed)
+	}
+	old, _ = expired(recs[6:], 1000)
+	if len(old) != 0 {
+		t.Error("newest save_size records must be kept", len(old))
+	}
+	cfg.SaveRecord = 0
+	if old, _ = expired(recs, 1000); len(old) != 0 {
+		t.Error("records must not be expired if save_record is 0", len(old))
+	}
+}
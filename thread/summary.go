@@ -0,0 +1,187 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package thread
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/boltdb/bolt"
+	"github.com/shingetsu-gou/shingetsu-gou/db"
+	"github.com/shingetsu-gou/shingetsu-gou/record"
+)
+
+//Bucket is a summary of records in a range of stamps.
+type Bucket struct {
+	From  int64
+	To    int64
+	Count int
+	Hash  string
+}
+
+var reBucket = regexp.MustCompile(`^(\d+)-(\d+)<>(\d+)<>([0-9a-f]{32})$`)
+
+//String returns bucket as "<from>-<to><><count><><md5 of idstrs>".
+func (b *Bucket) String() string {
+	return fmt.Sprintf("%d-%d<>%d<>%s", b.From, b.To, b.Count, b.Hash)
+}
+
+//ParseBucket parses the line made by Bucket.String.
+func ParseBucket(line string) (*Bucket, error) {
+	m := reBucket.FindStringSubmatch(line)
+	if m == nil {
+		return nil, errors.New("illegal bucket " + line)
+	}
+	b := &Bucket{Hash: m[4]}
+	var err error
+	if b.From, err = strconv.ParseInt(m[1], 10, 64); err != nil {
+		return nil, err
+	}
+	if b.To, err = strconv.ParseInt(m[2], 10, 64); err != nil {
+		return nil, err
+	}
+	if b.Count, err = strconv.Atoi(m[3]); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+//heads is slice of heads sorted by stamp and idstr.
+type heads []*record.Head
+
+//Len returns size of heads.
+func (h heads) Len() int {
+	return len(h)
+}
+
+//Swap swaps heads.
+func (h heads) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+//Less returns true if stamp of i is older, or id is smaller if stamps are same.
+func (h heads) Less(i, j int) bool {
+	if h[i].Stamp != h[j].Stamp {
+		return h[i].Stamp < h[j].Stamp
+	}
+	return h[i].ID < h[j].ID
+}
+
+//search returns the index of first head whose stamp is stamp or newer.
+func (h heads) search(stamp int64) int {
+	return sort.Search(len(h), func(i int) bool {
+		return h[i].Stamp >= stamp
+	})
+}
+
+//Summarizer makes summaries of records in a thread for finding differences
+//between nodes without listing all records.
+type Summarizer struct {
+	heads heads
+}
+
+//NewSummarizer returns Summarizer of all records in begin~end in the cache.
+func (c *Cache) NewSummarizer(begin, end int64) *Summarizer {
+	var hs heads
+	err := db.DB.View(func(tx *bolt.Tx) error {
+		var err error
+		hs, err = record.GetHeadsTX(tx, c.Datfile, begin, end)
+		return err
+	})
+	if err != nil {
+		log.Println(err)
+	}
+	return newSummarizer(hs)
+}
+
+//newSummarizer returns Summarizer of hs.
+func newSummarizer(hs heads) *Summarizer {
+	sort.Sort(hs)
+	return &Summarizer{
+		heads: hs,
+	}
+}
+
+//Bucket returns the summary of records in from~to.
+func (s *Summarizer) Bucket(from, to int64) *Bucket {
+	return s.bucket(from, to, s.heads.search(from), s.heads.search(to+1))
+}
+
+//bucket returns the summary of heads[i:j] as the range from~to.
+func (s *Summarizer) bucket(from, to int64, i, j int) *Bucket {
+	h := md5.New()
+	for _, r := range s.heads[i:j] {
+		fmt.Fprintln(h, r.Idstr())
+	}
+	return &Bucket{
+		From:  from,
+		To:    to,
+		Count: j - i,
+		Hash:  hex.EncodeToString(h.Sum(nil)),
+	}
+}
+
+//Split splits begin~end into at most n buckets which have almost same # of records.
+//Records with the same stamp are always in the same bucket.
+//returns nil if n is not positive.
+func (s *Summarizer) Split(begin, end int64, n int) []*Bucket {
+	if n <= 0 {
+		return nil
+	}
+	i, last := s.heads.search(begin), s.heads.search(end+1)
+	size := (last - i + n - 1) / n
+	if size == 0 {
+		return []*Bucket{s.bucket(begin, end, i, last)}
+	}
+	var bs []*Bucket
+	from := begin
+	for i < last {
+		j := i + size
+		if j >= last {
+			j = last
+		}
+		for j < last && s.heads[j].Stamp == s.heads[j-1].Stamp {
+			j++
+		}
+		to := end
+		if j < last {
+			to = s.heads[j].Stamp - 1
+		}
+		bs = append(bs, s.bucket(from, to, i, j))
+		from = to + 1
+		i = j
+	}
+	return bs
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package thread
+
+import (
+	"testing"
+
+	"github.com/shingetsu-gou/shingetsu-gou/record"
+)
+
+func TestSummarizer(t *testing.T) {
+	var hs, hs2 heads
+	for i := 0; i < 100; i++ {
+		hs = append(hs, &record.Head{Stamp: int64(1000 + i/2), ID: string('a' + rune(i%2))})
+		if i != 77 {
+			hs2 = append(hs2, &record.Head{Stamp: int64(1000 + i/2), ID: string('a' + rune(i%2))})
+		}
+	}
+	s := newSummarizer(hs)
+	s2 := newSummarizer(hs2)
+	bs := s.Split(0, 2000, 16)
+	if bs[0].From != 0 || bs[len(bs)-1].To != 2000 {
+		t.Fatal("illegal range", bs[0], bs[len(bs)-1])
+	}
+	total := 0
+	diff := 0
+	for i, b := range bs {
+		total += b.Count
+		if b.Count%2 != 0 {
+			t.Error("records with same stamp are splitted", b)
+		}
+		if i > 0 && bs[i-1].To+1 != b.From {
+			t.Error("buckets are not continuous", bs[i-1], b)
+		}
+		bb, err := ParseBucket(b.String())
+		if err != nil || *bb != *b {
+			t.Error("cannot parse", b, err)
+		}
+		if *s.Bucket(b.From, b.To) != *b {
+			t.Error("bucket differs", b)
+		}
+		if s2.Bucket(b.From, b.To).Hash != b.Hash {
+			diff++
+			if b.From > 1038 || b.To < 1038 {
+				t.Error("wrong bucket differs", b)
+			}
+		}
+	}
+	if total != 100 || diff != 1 {
+		t.Fatal("illegal split", total, diff)
+	}
+	if bs := s.Split(3000, 4000, 16); len(bs) != 1 || bs[0].Count != 0 {
+		t.Fatal("illegal empty split", bs)
+	}
+	if bs := s.Split(0, 2000, 0); bs != nil {
+		t.Fatal("split into 0 buckets", bs)
+	}
+}
@@ -0,0 +1,289 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package updateque
+
+import (
+	"encoding/json"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/shingetsu-gou/shingetsu-gou/db"
+	"github.com/shingetsu-gou/shingetsu-gou/node"
+	"github.com/shingetsu-gou/shingetsu-gou/node/manager"
+	"github.com/shingetsu-gou/shingetsu-gou/record"
+)
+
+//states of updates in the queue.
+const (
+	Pending = iota
+	Failed
+	Delivered
+)
+
+const (
+	initialBackoff = time.Minute
+	maxBackoff     = 6 * time.Hour
+	maxTries       = 8
+	keepDone       = 3 * 24 * time.Hour
+	checkInterval  = time.Minute
+)
+
+//kick is for waking up the queue worker.
+var kick = make(chan struct{}, 1)
+
+//Update is an /update notification to a node in the queue.
+type Update struct {
+	record.Head
+	Node     string
+	Tell     string
+	State    int
+	Tries    int
+	Next     int64
+	Added    int64
+	Modified int64
+	Err      string
+}
+
+//key returns key of u in the db.
+func (u *Update) key() []byte {
+	return db.ToKey(u.Datfile, u.Stamp, u.ID, u.Node)
+}
+
+//message returns /update message of u.
+func (u *Update) message() string {
+	return strings.Join([]string{"/update", u.Datfile, strconv.FormatInt(u.Stamp, 10), u.ID, u.Tell}, "/")
+}
+
+//put saves u to the db.
+func (u *Update) put(tx *bolt.Tx) error {
+	u.Modified = time.Now().Unix()
+	return db.Put(tx, "updateQue", u.key(), u)
+}
+
+//backoff returns the duration before the next try after tries failures.
+func backoff(tries int) time.Duration {
+	d := initialBackoff
+	for i := 1; i < tries && d < maxBackoff; i++ {
+		d *= 2
+	}
+	if d > maxBackoff {
+		d = maxBackoff
+	}
+	return d
+}
+
+//TellUpdate queues /update notifications of the record to nodes related to the thread.
+//tellstr in the message is made from n, or from mynode if n is nil.
+func TellUpdate(datfile string, stamp int64, id string, n *node.Node) {
+	var tellstr string
+	if n != nil {
+		tellstr = n.Toxstring()
+	}
+	ns := manager.NodesForUpdate(datfile)
+	log.Println("queueing updates to #", len(ns))
+	now := time.Now().Unix()
+	err := db.DB.Update(func(tx *bolt.Tx) error {
+		for _, nn := range ns {
+			tell := tellstr
+			if n == nil {
+				tell = node.MeFor(nn, true).Toxstring()
+			}
+			u := &Update{
+				Head:  record.Head{Datfile: datfile, Stamp: stamp, ID: id},
+				Node:  nn.Nodestr,
+				Tell:  tell,
+				Next:  now,
+				Added: now,
+			}
+			if err := u.put(tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	select {
+	case kick <- struct{}{}:
+	default:
+	}
+}
+
+//List returns updates in the state, sorted by modified time from the newest.
+func List(state int) []*Update {
+	var us []*Update
+	err := db.DB.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte("updateQue"))
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			u := &Update{}
+			if err := json.Unmarshal(v, u); err != nil {
+				return err
+			}
+			if u.State == state {
+				us = append(us, u)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		log.Println(err)
+	}
+	sort.Sort(updates(us))
+	return us
+}
+
+//updates is slice of Update sorted by modified time from the newest.
+type updates []*Update
+
+//Len returns length of updates.
+func (u updates) Len() int {
+	return len(u)
+}
+
+//Swap swaps order of updates.
+func (u updates) Swap(i, j int) {
+	u[i], u[j] = u[j], u[i]
+}
+
+//Less returns true if updates i is newer than j.
+func (u updates) Less(i, j int) bool {
+	return u[i].Modified > u[j].Modified
+}
+
+//RetryFailed makes failed updates pending again.
+func RetryFailed() {
+	failed := List(Failed)
+	err := db.DB.Update(func(tx *bolt.Tx) error {
+		for _, u := range failed {
+			u.State = Pending
+			u.Tries = 0
+			u.Next = time.Now().Unix()
+			if err := u.put(tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Println(err)
+	}
+	select {
+	case kick <- struct{}{}:
+	default:
+	}
+}
+
+//due returns pending updates whose next try time has come,
+//and removes delivered and failed updates older than keepDone.
+func due() []*Update {
+	var us []*Update
+	now := time.Now()
+	err := db.DB.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte("updateQue"))
+		if b == nil {
+			return nil
+		}
+		var old [][]byte
+		err := b.ForEach(func(k, v []byte) error {
+			u := &Update{}
+			if err := json.Unmarshal(v, u); err != nil {
+				return err
+			}
+			switch {
+			case u.State == Pending && u.Next <= now.Unix():
+				us = append(us, u)
+			case u.State != Pending && u.Modified < now.Add(-keepDone).Unix():
+				old = append(old, k)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, k := range old {
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Println(err)
+	}
+	return us
+}
+
+//deliver tells the update u to the node and saves its result.
+func deliver(u *Update) {
+	n, err := node.New(u.Node)
+	if err == nil {
+		_, err = n.Talk(u.message(), nil)
+	}
+	u.Tries++
+	switch {
+	case err == nil:
+		u.State = Delivered
+		u.Err = ""
+	case u.Tries >= maxTries:
+		u.State = Failed
+		u.Err = err.Error()
+	default:
+		u.Next = time.Now().Add(backoff(u.Tries)).Unix()
+		u.Err = err.Error()
+	}
+	err = db.DB.Update(func(tx *bolt.Tx) error {
+		return u.put(tx)
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+//Run delivers updates in the queue forever.
+//updates which failed to be delivered are retried with exponential backoff.
+func Run() {
+	for {
+		for _, u := range due() {
+			deliver(u)
+		}
+		select {
+		case <-kick:
+		case <-time.After(checkInterval):
+		}
+	}
+}
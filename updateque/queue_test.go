@@ -0,0 +1,152 @@
+/*
+ * Copyright (c) 2015, Shinya Yagyu
+ * All rights reserved.
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions are met:
+ *
+ * 1. Redistributions of source code must retain the above copyright notice,
+ *    this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright notice,
+ *    this list of conditions and the following disclaimer in the documentation
+ *    and/or other materials provided with the distribution.
+ * 3. Neither the name of the copyright holder nor the names of its
+ *    contributors may be used to endorse or promote products derived from this
+ *    software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+ * AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+ * LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+ * CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+ * SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+ * INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+ * CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ * ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+ * POSSIBILITY OF SUCH DAMAGE.
+ */
+
+package updateque
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/shingetsu-gou/shingetsu-gou/cfg"
+	"github.com/shingetsu-gou/shingetsu-gou/db"
+	"github.com/shingetsu-gou/shingetsu-gou/record"
+)
+
+//setupDB opens a new db in a temporary directory and returns the directory.
+func setupDB(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "updateque")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.RunDir = dir
+	db.Setup()
+	return dir
+}
+
+//putUpdate saves u to the db as is.
+func putUpdate(t *testing.T, u *Update) {
+	err := db.DB.Update(func(tx *bolt.Tx) error {
+		return db.Put(tx, "updateQue", u.key(), u)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+//newUpdate returns a pending update to nodestr.
+func newUpdate(nodestr string) *Update {
+	now := time.Now().Unix()
+	return &Update{
+		Head:  record.Head{Datfile: "thread_a", Stamp: 1, ID: "0123456789abcdef0123456789abcdef"},
+		Node:  nodestr,
+		Tell:  ":8000+server.cgi",
+		Next:  now,
+		Added: now,
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for tries, d := range map[int]time.Duration{
+		1:  initialBackoff,
+		2:  2 * initialBackoff,
+		3:  4 * initialBackoff,
+		20: maxBackoff,
+	} {
+		if b := backoff(tries); b != d {
+			t.Error("illegal backoff", tries, b)
+		}
+	}
+}
+
+func TestRetry(t *testing.T) {
+	dir := setupDB(t)
+	defer os.RemoveAll(dir)
+	defer db.DB.Close()
+	s := httptest.NewServer(http.NotFoundHandler())
+	nodestr := strings.TrimPrefix(s.URL, "http://") + "/server.cgi"
+	s.Close() //connections to the node are refused.
+
+	putUpdate(t, newUpdate(nodestr))
+	for i := 1; i <= maxTries; i++ {
+		us := due()
+		if len(us) != 1 {
+			t.Fatal("update is not due", i, len(us))
+		}
+		deliver(us[0])
+		u := us[0]
+		if u.Tries != i || u.Err == "" {
+			t.Fatal("failure is not recorded", u.Tries, u.Err)
+		}
+		if i == maxTries {
+			break
+		}
+		if u.State != Pending {
+			t.Fatal("update is given up before the last try", i)
+		}
+		if next := time.Now().Add(backoff(i)).Unix(); u.Next < next-1 || u.Next > next {
+			t.Error("illegal next try", i, u.Next, next)
+		}
+		if len(due()) != 0 {
+			t.Fatal("update is retried before the backoff")
+		}
+		u.Next = time.Now().Unix() //as if the backoff passed.
+		putUpdate(t, u)
+	}
+	if len(due()) != 0 || len(List(Failed)) != 1 {
+		t.Fatal("update is not given up after the last try")
+	}
+	u := List(Failed)[0]
+	u.Modified = time.Now().Add(-keepDone).Unix() - 1
+	putUpdate(t, u)
+	if len(due()) != 0 || len(List(Failed)) != 0 {
+		t.Error("failed update is not dropped")
+	}
+}
+
+func TestRestart(t *testing.T) {
+	dir := setupDB(t)
+	defer os.RemoveAll(dir)
+	putUpdate(t, newUpdate("127.0.0.1:8000/server.cgi"))
+	if err := db.DB.Close(); err != nil {
+		t.Fatal(err)
+	}
+	db.Setup()
+	defer db.DB.Close()
+	if len(List(Pending)) != 1 {
+		t.Fatal("update is lost by restart")
+	}
+	if us := due(); len(us) != 1 || us[0].Node != "127.0.0.1:8000/server.cgi" {
+		t.Error("update is not due after restart")
+	}
+}
@@ -29,11 +29,14 @@
 package updateque
 
 import (
+	"encoding/binary"
 	"log"
 	"sync"
 	"time"
 
+	"github.com/boltdb/bolt"
 	"github.com/shingetsu-gou/shingetsu-gou/cfg"
+	"github.com/shingetsu-gou/shingetsu-gou/db"
 	"github.com/shingetsu-gou/shingetsu-gou/node"
 	"github.com/shingetsu-gou/shingetsu-gou/node/manager"
 	"github.com/shingetsu-gou/shingetsu-gou/recentlist"
@@ -41,11 +44,6 @@ import (
 	"github.com/shingetsu-gou/shingetsu-gou/thread"
 )
 
-//UpdateQue is for telling updates of records.
-//it records hash of updated records for 1 hour not to tell again.
-var mutex sync.Mutex
-var updated = make(map[[16]byte]time.Time)
-
 //UpdateNodes do doUpdateNode for each records using related nodes.
 //if success to doUpdateNode, add node to updatelist and recentlist and
 //removes the record from queue.
@@ -57,20 +55,48 @@ func UpdateNodes(rec *record.Record, n *node.Node) {
 	if !cfg.HeavyMoon {
 		return
 	}
-	if ca := thread.NewCache(rec.Datfile); !ca.Exists() {
+	if ca := thread.NewCache(rec.Datfile); !ca.Exists() && !ca.Evicted() {
 		ca.Subscribe()
 	}
 }
 
-//deleteOldUpdated removes old updated records from updated map.
-func deleteOldUpdated() {
+//isUpdated returns true if rec was already broadcasted within an hour,
+//or marks it as broadcasted.
+//old marks are removed here.
+func isUpdated(rec *record.Record) bool {
 	const oldUpdated = time.Hour
 
-	for k, v := range updated {
-		if time.Now().After(v.Add(oldUpdated)) {
-			delete(updated, k)
+	var exist bool
+	err := db.DB.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte("updated"))
+		if err != nil {
+			return err
+		}
+		old := time.Now().Add(-oldUpdated).Unix()
+		var olds [][]byte
+		err = b.ForEach(func(k, v []byte) error {
+			if int64(binary.BigEndian.Uint64(v)) < old {
+				olds = append(olds, k)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, k := range olds {
+			if err := b.Delete(k); err != nil {
+				return err
+			}
 		}
+		if exist = b.Get(rec.ToKey()) != nil; exist {
+			return nil
+		}
+		return db.Put(tx, "updated", rec.ToKey(), time.Now().Unix())
+	})
+	if err != nil {
+		log.Println(err)
 	}
+	return exist
 }
 
 //RecordChannel is for informing record was gotten.
@@ -119,30 +145,21 @@ var UpdatedRecord RecordChannel
 //if can get data (even if spam) return true, if fails to get, return false.
 //if no fail, broadcast updates to node in cache and added n to nodelist and searchlist.
 func doUpdateNode(rec *record.Record, n *node.Node) bool {
-	mutex.Lock()
-	deleteOldUpdated()
-	if _, exist := updated[rec.Hash()]; exist {
+	if isUpdated(rec) {
 		log.Println("already broadcasted", rec.ID)
-		mutex.Unlock()
 		return true
 	}
-	updated[rec.Hash()] = time.Now()
-	mutex.Unlock()
 
 	ca := thread.NewCache(rec.Datfile)
 	var err error
 	if !ca.Exists() || n == nil {
 		log.Println("no cache or updates by myself, broadcast updates.")
 		UpdatedRecord.register(rec.Head)
-		manager.TellUpdate(ca.Datfile, rec.Stamp, rec.ID, n)
+		TellUpdate(ca.Datfile, rec.Stamp, rec.ID, n)
 		if UpdatedRecord.wait() || n != nil {
 			log.Println(rec.ID, "was gotten or don't have the record")
 		} else {
-			log.Println(rec.ID, "was NOT gotten, will call updates later")
-			go func() {
-				time.Sleep(10 * time.Minute)
-				UpdateNodes(rec, n)
-			}()
+			log.Println(rec.ID, "was NOT gotten yet, updates are retried in the queue")
 		}
 		return true
 	}
@@ -155,9 +172,12 @@ func doUpdateNode(rec *record.Record, n *node.Node) bool {
 	case cfg.ErrSpam:
 		log.Println("marked spam")
 		return true
+	case cfg.ErrForged:
+		log.Println("marked forged")
+		return true
 	default:
 		log.Println("telling update")
-		manager.TellUpdate(ca.Datfile, rec.Stamp, rec.ID, nil)
+		TellUpdate(ca.Datfile, rec.Stamp, rec.ID, nil)
 		manager.Join(n)
 		return true
 	}
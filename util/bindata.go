@@ -1,5 +1,6 @@
 // Code generated by go-bindata.
 // sources:
+// gou_template/update_queue.txt
 // www/00default.css
 // www/00initialize.js
 // www/20jump.js
@@ -10,6 +11,7 @@
 // www/20tagedit.js
 // www/20textarea.js
 // www/21resanchor.js
+// www/30liveupdate.js
 // www/40recform.js
 // www/41postadvanced.js
 // www/arazuki_saku.png
@@ -44,6 +46,7 @@
 // file/initnode.txt
 // file/message-en.txt
 // file/message-ja.txt
+// file/moderator.txt
 // file/motd.txt
 // file/node_allow.txt
 // file/node_deny.txt
@@ -54,18 +57,22 @@
 // gou_template/delete_record.txt
 // gou_template/edit_tag.txt
 // gou_template/footer.txt
+// gou_template/fsck.txt
 // gou_template/header.txt
 // gou_template/index_list.txt
 // gou_template/jump.txt
 // gou_template/list_item.txt
 // gou_template/menubar.txt
 // gou_template/new_element_form.txt
+// gou_template/node_health.txt
 // gou_template/page_navi.txt
 // gou_template/post_form.txt
 // gou_template/record.txt
 // gou_template/remove_file_form.txt
+// gou_template/retention.txt
 // gou_template/rss1.txt
 // gou_template/search_form.txt
+// gou_template/search_result.txt
 // gou_template/status.txt
 // gou_template/thread_bottom.txt
 // gou_template/thread_tags.txt
@@ -138,7 +145,27 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _www00defaultCss = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x7c\x53\xcd\x6e\xdb\x3c\x10\xbc\xeb\x29\x16\xf1\xe5\xfb\x0c\x4b\x91\x13\xa4\x07\x1a\x28\x50\xa4\x45\xd0\x43\x80\x00\xee\x0b\xd0\xe4\x4a\x62\x43\x91\x04\xb9\xf2\x4f\x83\xbc\x7b\x21\xea\x27\x52\x12\xf5\x60\x43\x98\x9d\x59\xee\x0e\x87\xd7\x6b\xd8\xf3\xe7\x06\xbe\x63\xc1\x1b\x4d\x70\xbf\xdf\x27\xb0\x86\x7b\xeb\x2e\x5e\x95\x15\xc1\x7f\xe2\x7f\xb8\xc9\xf3\xbb\xf4\x26\xdf\xde\x40\xa8\x94\x79\xf8\xf1\x2b\x34\xf0\xe4\xed\x6f\x14\x94\x25\xb0\xbe\x4e\x0e\x56\x5e\xe0\x25\x01\x00\x38\x70\xf1\x5c\x7a\xdb\x18\x99\x0a\xab\xad\x67\xb0\x2a\x8a\x62\x97\xbc\x26\xd5\x76\x03\xd5\x16\x78\x4f\x1c\xaa\x79\x8e\xbb\x08\x10\x9e\x29\x95\x28\xac\xe7\xa4\xac\x61\x60\xac\xc1\x56\x28\x69\xb9\xb7\x10\x45\x47\xc9\x0c\x9e\x9c\x0d\xff\xa0\x16\x42\x44\xaa\xec\x29\x35\xf7\xa5\x32\xe9\xc1\x12\xd9\x9a\xc1\x16\xeb\x58\x56\xc7\x8c\x4b\xe9\x31\x84\x9e\x17\xe7\xe2\x5a\x95\x86\x81\x40\x43\xe8\xbb\x79\x0b\x6b\x28\x0d\x74\xd1\xc8\x40\x11\xd7\x2a\xb6\xe7\xac\xb2\x47\xf4\x1f\x96\xcc\x77\x4b\x73\x71\x1e\x57\xc8\x0c\xaf\x71\x49\x16\xcf\x3a\x61\x7b\x23\x0c\x0e\x56\xcb\xa8\x08\xaa\x34\x73\x85\xc7\x58\x21\x7e\xd0\x98\x05\xab\x95\x04\x1a\xf6\x3d\x58\x2f\xd1\x33\xd8\xba\x33\x74\xa5\x95\x10\x62\x07\xb1\xe8\xb8\x94\xca\x94\x0c\xee\xdc\x79\xfc\x45\x4b\xde\x5b\x10\x63\x11\x8f\x77\xd6\x35\x0e\xbe\xc2\x7a\xd9\x73\xde\x5d\xfd\x48\x95\xba\xfd\xa3\xcd\x7b\x64\x98\xb1\x1f\x23\xf5\xdd\xa6\xe3\x00\x03\xae\xb1\xa0\xf1\xaa\xde\x7a\xa8\xe3\x5c\x3f\x52\x56\xce\xe3\x51\xe1\x69\xd9\x82\xd1\xe2\xa9\x03\xbb\x49\x40\x26\xc0\xa9\x52\x84\x69\x70\x5c\x20\x03\xe7\x63\x38\x1b\x9d\x91\x75\x35\x9a\x06\xb4\x82\x97\xce\x4d\xa9\x82\xd3\xfc\xc2\x40\x19\xad\x0c\x4e\xdb\x8d\x2b\x9c\x5b\xb5\x56\x90\x11\x2f\xfb\xf1\xba\x44\xa9\x3f\xc8\x20\xd4\x5c\xeb\x36\x69\xaf\xc9\x84\xb0\xf8\x44\x5a\x4e\x98\xb6\x9a\xe7\x21\x0b\x4d\xb9\xc0\x28\x3d\xa2\x69\x39\x2e\xf6\xd8\x80\x1b\xc9\xf3\x2c\xde\xde\xde\xee\x3e\x7b\x37\xf9\x0c\x25\xeb\x22\xf4\x9a\x14\xd6\xd7\xab\x42\x69\x42\xdf\x7e\x7e\xfe\xe8\xe6\xde\x4f\xe1\xbe\x01\xf1\xf2\xa3\x7a\x38\x64\x2a\x7d\x3b\x78\x0c\xd3\xc4\xce\xed\x97\xe1\x0e\x23\x58\xf0\x5a\xe9\x0b\x83\xab\xc7\x3d\x3c\x3d\x58\xaa\x94\xb8\xda\xc0\xa3\x35\x7c\x03\x3f\x9f\xbe\xb5\x1f\x3d\xbc\x81\xc0\x4d\x48\x03\x7a\x15\x93\xfc\x37\x00\x00\xff\xff\x0a\xe2\xb2\x10\x30\x05\x00\x00")
+var _gou_templateUpdate_queueTxt = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x85\x53\x51\x6b\xdb\x30\x10\x7e\xcf\xaf\x38\x44\x06\x49\xa1\xf6\xd6\x87\x31\x8a\x1d\x18\x4d\x19\x79\x68\x60\xb4\x7b\xd8\x53\x50\xad\x73\x2c\xb0\x65\x47\x92\x47\x83\xf1\x7f\xdf\x49\xb6\x53\x3b\xc9\xba\x97\xe4\xac\xbb\xef\xbb\xbb\xef\x93\x9a\x26\xbc\x99\xc1\x43\x59\x1d\xb5\xdc\x67\x16\x16\xc9\x12\xee\x3e\x7f\xf9\x0a\xcf\x99\x54\x47\x0e\xbf\xf9\xfe\x58\x07\x33\xb8\x09\xdb\x76\xd6\x34\x02\x53\xa9\x10\x58\x5d\x09\x6e\x71\x77\xa8\xb1\x46\xe6\x33\x73\x5d\x96\xf6\x3e\x0e\xe8\x23\xaa\x56\x4d\x13\x3c\xa1\x31\x7c\x8f\x81\x40\x93\xec\xc6\xf5\x6d\x1b\x85\xd5\x8a\x20\xa0\xb9\xda\x23\xcc\x0f\x04\xfb\xe9\x32\x06\x08\x0d\x10\x65\x77\x44\x20\x95\xc0\x37\xf0\xb4\x03\x17\x95\x06\x5b\x5e\x10\x03\x2c\x9a\x26\x47\xe5\x0e\x7e\x79\x6a\xd3\xb6\xcb\x28\x24\x20\xe1\x89\x59\xa6\xc0\x95\x80\x05\x1e\x06\x0c\xb0\x94\xcb\x1c\x05\x5b\x8e\x40\x7d\xbf\xb4\xd4\x05\x14\x68\xb3\x52\xc4\xac\x2a\x8d\x65\xc0\x13\x2b\x4b\x15\xb3\x7e\xb1\xe0\xbb\x28\xa4\x7a\xf8\xb1\x69\xdb\xb0\xdb\xc5\x30\xd7\x8a\xc0\x52\x55\xb5\x05\x7b\xac\x30\x66\x99\x14\x02\x15\x03\x45\x0d\x63\x96\x14\x82\xc1\x1f\x9e\xd7\x14\x6b\xb4\xfa\xc8\x20\xfc\x2f\xc8\xc8\x77\xd0\xd0\xfc\x59\x8a\xb6\xbd\x0e\x36\xf5\x6b\x21\xed\x05\x62\x10\xdf\xb7\xdd\x75\x9b\x3b\x8a\x24\xe7\xc6\xc4\xec\xd5\xaa\x9e\x2e\x0a\xdd\xf2\xbd\x6a\x48\x92\x79\x45\x3a\x05\x2f\x74\xb2\xfc\x35\xc7\x81\xa3\xfb\xf0\xbf\xb7\x49\x49\x66\x29\x43\xea\xf6\x23\x5a\xdd\x05\x2e\xcc\x56\xe7\x53\x59\x69\x73\x7f\x0d\x28\xe7\xf2\x9b\xf5\x29\x3c\x2f\x55\xa5\x18\x2a\x3f\x22\xd4\xd2\xdd\x80\x11\x8b\x9b\x7f\x6c\x7e\x45\xbb\x49\xb5\x67\xb4\xc9\x45\x0b\x7c\xb3\x3b\x92\xc9\x65\x00\x73\x83\x57\x6a\x3a\xcf\xc5\x8e\xdb\xae\xca\x0b\xf5\xcf\xa1\x49\x20\xbb\x43\xad\x4b\x3d\x1e\x9d\xa2\x5e\x95\xf7\xab\x5f\xdf\xc7\xe7\x2a\x9f\xa9\x27\x56\x11\x87\x4c\x63\xfa\x6e\xee\x0b\x7d\x72\xd1\x5d\xc6\xa6\x31\x56\x3f\xaa\x84\x64\x82\x45\x4a\x36\xaf\xd1\xc7\xf3\x3a\x58\x73\xeb\x0e\x96\xe4\x3b\x4d\x78\x35\xe7\xc6\xe3\x2b\x1a\x4c\x4c\x1a\x9a\x8a\xab\xc1\x66\x63\x79\x51\x31\xa0\x01\xf9\xad\x8f\xfd\x1c\x75\xf0\xec\xe2\x8e\x3a\x2f\x13\x9e\x5b\x59\x78\xe6\xfe\x3c\x0a\x1d\xc9\x8a\x56\xad\xb4\x54\x36\x05\xf6\x29\xf8\x66\x98\xab\xd8\xac\xbd\x2a\x93\x96\x9e\x72\x3b\x78\x7d\x99\x7a\x39\x19\x7c\x96\xfb\xd0\xe7\xc9\x60\x5b\xb2\x79\x6a\xf1\x24\xfd\x54\x0a\x99\x4a\xf7\x4a\xc6\xfe\x5e\x4e\xf2\xa8\xf5\x38\x33\x31\xf5\xf4\x7e\xe8\xd4\xbd\x8b\xe9\xb3\x1a\x47\x14\xd0\xff\x5f\x4e\x11\x84\x01\x7d\x05\x00\x00")
+
+func gou_templateUpdate_queueTxtBytes() ([]byte, error) {
+	return bindataRead(
+		_gou_templateUpdate_queueTxt,
+		"gou_template/update_queue.txt",
+	)
+}
+
+func gou_templateUpdate_queueTxt() (*asset, error) {
+	bytes, err := gou_templateUpdate_queueTxtBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "gou_template/update_queue.txt", size: 1405, mode: os.FileMode(420), modTime: time.Unix(1792195881, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _www00defaultCss = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x7d\x53\x5d\x6b\xdb\x30\x14\x7d\xcf\xaf\xb8\x34\x2f\x5b\x89\x5d\x27\xa5\x85\x38\x30\x18\xdd\x28\x7b\x28\x04\xb2\x3f\x20\x4b\xd7\xb6\x56\x59\x12\x92\x9c\x8f\x95\xfe\xf7\xc9\xf2\x47\x9c\xb4\x5e\x20\x21\xdc\x7b\xee\xd1\xd1\xb9\x47\x77\xb7\xb0\x23\xaf\x35\xfc\xc0\x9c\xd4\xc2\xc1\xd3\x6e\x37\x83\x5b\x78\x52\xfa\x64\x78\x51\x3a\xf8\x42\xbf\xc2\x2a\x49\x1e\xa2\x55\xb2\x5c\x81\x2d\xb9\x7c\xfe\xf9\xdb\xd6\xb0\x35\xea\x0f\x52\x17\x7b\xf4\xdd\x2c\x53\xec\x04\x6f\x33\xf0\x9f\x8c\xd0\xd7\xc2\xa8\x5a\xb2\x88\x2a\xa1\x4c\x0a\xf3\x3c\xcf\x37\xb3\xf7\x59\xb9\x5c\x40\xb9\x04\xd2\x01\xfb\x6e\x92\xe0\x26\x14\x1c\x1e\x5d\xc4\x90\x2a\x43\x1c\x57\x32\x05\xa9\x24\x36\x83\xcc\x4d\x73\x53\x9a\xb7\x90\x58\xe2\x41\x2b\xfb\x1f\x68\x4e\x69\x80\xb2\x0e\x52\x11\x53\x70\x19\x65\xca\x39\x55\xa5\xb0\xc4\x2a\xb4\xf9\x3e\x26\x8c\x19\xb4\xb6\xc3\x05\x5d\x44\xf0\xc2\x4b\xa2\x28\x1d\x9a\x56\x6f\xae\xa4\x8b\xac\x3b\x09\x4c\x81\x3b\x0f\x08\xf4\x24\x2d\xd5\x1e\xcd\x87\x4b\x26\x9b\x29\x5d\x84\x84\x2b\xc4\x92\x54\x38\x35\x16\xce\x3a\x60\xb3\x91\x14\x32\x25\x58\x98\xb0\x5e\xd3\xe5\x84\xc1\x73\x27\xce\x95\x29\x90\x5d\x51\xae\xd7\xeb\x09\xbb\x05\x97\x18\xb9\xd2\x8b\x2b\xca\x86\xc4\x91\x4c\x60\x6c\x95\xe0\x0c\x5c\x4f\x93\x29\xc3\xd0\xf3\x2c\xf5\x11\xda\x96\x5f\x01\xdd\x40\x68\x6a\x6f\x1c\x97\x45\x0a\x0f\xbe\xdb\x7f\x83\xaf\xd7\x3e\x86\x6c\x05\xa5\x5a\xe9\x5a\xc3\x37\x9f\xb9\xc9\xc5\x91\x36\x3f\x03\x94\x89\xe6\xc7\x2d\xae\x2b\xbd\xc6\x4e\x46\x64\x5a\xbb\x06\x01\x7d\x5d\x60\xee\x86\x7d\x9f\x39\xf8\xfe\x72\x7e\x80\xcc\xb5\xc1\x3d\xc7\xc3\xb4\x05\xc3\x9e\xc6\x0e\x6c\x46\x29\x1b\x15\x0e\x25\x77\x18\x59\x4d\xa8\xcf\x8d\x67\x6e\x4e\xa8\x45\xec\x94\xae\x50\xd6\x7e\x0b\xf0\xd6\xba\xc9\xb8\xd5\x82\x9c\x7c\xb8\x64\xb3\x9a\x31\xdd\x70\x85\x63\x33\xed\x47\x62\x47\x8a\x4e\x5e\x1b\x4b\xfe\xd7\xb3\xdb\x8a\x08\xd1\xc4\xd5\x5f\xf3\x0c\x98\x7c\x67\x0d\xc6\x8e\xa9\xae\x43\x55\x17\x13\x88\xc2\x20\xca\x06\xa3\x03\xc7\x02\xf4\x00\xbe\x4c\xdf\xfd\xfd\xfd\xe6\xb3\xc7\x97\x5c\x54\xbd\x17\xa1\xf4\x3e\xf3\x11\xae\xe6\x39\x17\xfe\xd1\x35\x7f\x3f\x7f\xb9\x97\xde\x8f\xcb\x1d\x81\x17\xf2\x71\xba\x3f\x64\x3c\x7a\x3e\x78\x08\xd3\xc8\xce\xe5\x63\xbf\xc3\x50\xcc\x49\xc5\x85\x5f\xcf\xcd\xcb\x0e\xb6\xcf\xca\x95\x9c\xde\x2c\xe0\x45\x49\xb2\x80\x5f\xdb\xef\xcd\x9f\xae\xbc\x00\x4b\xa4\x8d\x2c\x1a\x1e\x92\xfc\x0f\x3f\x5c\x60\xa1\x75\x05\x00\x00")
 
 func www00defaultCssBytes() ([]byte, error) {
 	return bindataRead(
@@ -153,7 +180,7 @@ func www00defaultCss() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "www/00default.css", size: 1328, mode: os.FileMode(420), modTime: time.Unix(1502257508, 0)}
+	info := bindataFileInfo{name: "www/00default.css", size: 1397, mode: os.FileMode(420), modTime: time.Unix(1792194749, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -338,6 +365,26 @@ func www21resanchorJs() (*asset, error) {
 	return a, nil
 }
 
+var _www30liveupdateJs = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x7d\x52\xc1\x6e\x9c\x30\x10\xbd\xef\x57\x4c\x92\x95\xb0\x93\xac\xa1\x39\x54\xa9\x5a\x72\x89\x7a\xa9\xa2\xb6\xea\x9e\xaa\x64\x0f\x0e\x1e\xc0\x92\xd7\x46\xb6\x61\x45\xb3\xfb\xef\xb1\x97\x5d\xa0\x3d\xd4\x12\x36\x8c\x1f\x6f\xde\xbc\x99\xf4\x1a\x9e\x64\x87\xd0\x36\x82\x7b\x04\x53\x82\xc5\xc2\x58\xe1\xe0\xb5\x07\x87\xb6\x43\xbb\x72\xa8\x3d\x60\x17\x76\xc7\x16\x70\x0d\x8f\xa6\xe9\xad\xac\x6a\x0f\xe4\x91\xc2\x5d\xf6\xe1\x23\xac\x6b\xa9\x7b\x0e\xbf\x79\xd5\xb7\x11\x93\x2e\x16\x2e\x84\x2a\xf4\xae\x65\x52\x4b\x2f\xb9\x92\x7f\x90\x94\xad\x2e\xbc\x34\x1a\x08\x85\xb7\x05\x84\xd5\x71\x0b\xcb\x73\xce\x1c\x96\x24\x11\xea\xea\xf4\x9d\xd0\xcf\x23\xa6\x94\x0a\x87\x7b\xa9\x9b\xd6\x3f\x6b\xbe\xc5\xfc\x32\x46\x2f\x37\x09\x65\xa5\xb4\xce\x13\xca\x3a\xae\xc8\xe9\x2f\x59\x02\xb9\xd8\x49\x2d\xcc\x8e\x7d\x8d\xea\xd7\xa6\xb5\x05\xc2\x7e\x3f\x26\x64\x0a\x75\xe5\x6b\xc8\x73\xc8\x62\xfc\x22\xf2\x9d\x95\xc5\x65\xd1\xb7\x56\x0f\x7c\x87\xe3\x9e\xa6\x60\xb4\xea\xc3\x06\xbe\x46\xd0\xb8\x43\xe7\xa1\xe1\x15\xb2\x31\x6b\xfa\x92\x92\xe6\x39\x5b\x7d\xda\xdc\xec\xe3\xc1\x57\xe5\xe6\xed\xfe\x40\x97\x29\xf3\x01\x4d\x94\x29\x78\x74\x81\x35\xdc\xd7\xb1\x10\xfa\xff\x9c\xb1\x7e\x37\x88\xcf\x63\x46\x98\x95\x43\x26\x9f\xad\x31\xfe\x67\x60\x84\x1b\x48\xaa\xd0\xcd\x1d\xef\x59\x51\xc9\x74\x68\x5d\x9a\x84\xf8\xb1\xbe\x81\x7a\x20\x64\x5c\x88\x23\xdb\x93\x74\x1e\x35\x5a\x92\x0c\xde\x24\xb7\x30\x35\xeb\x2f\x4f\xa2\x9a\x80\x09\x52\xbe\xad\x7f\x7c\x0f\x35\x58\x87\x04\x59\x98\x1f\x7e\xa2\x3e\xfb\x10\x9a\x75\x65\x63\xda\x00\x67\x52\x30\xd7\xbe\x3a\x6f\x49\x76\x0b\xf7\x94\x9e\xbd\x7f\x80\x6c\xce\xfe\xaf\x03\x93\x0b\xe3\xb4\x14\x46\x7b\x2e\x83\xd6\x61\x1e\xbe\x08\xf5\x10\x26\xa0\xf6\x5b\x45\x62\xa2\xf8\x32\x13\x32\xf9\xb3\x35\x42\x96\xfd\xaf\xa1\xf5\x64\xa2\x99\x81\xc7\xc1\xe0\x4d\x83\x5a\xcc\x40\xac\xa8\xa5\x12\x16\x35\xa1\x27\xfc\x21\x9c\xf1\x79\x07\xd4\x23\xdd\x93\x43\x03\x00\x00")
+
+func www30liveupdateJsBytes() ([]byte, error) {
+	return bindataRead(
+		_www30liveupdateJs,
+		"www/30liveupdate.js",
+	)
+}
+
+func www30liveupdateJs() (*asset, error) {
+	bytes, err := www30liveupdateJsBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "www/30liveupdate.js", size: 835, mode: os.FileMode(420), modTime: time.Unix(1792195694, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _www40recformJs = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xbc\x56\xc1\x8e\xdb\x36\x10\xbd\xfb\x2b\x06\x6a\x10\x52\xb1\x2b\x79\xd3\xa2\x87\x15\x14\xa0\x68\x8b\xa0\x87\x5e\xda\xed\x69\x61\x14\xb4\x34\x2b\x11\x91\x48\x81\xa4\x9c\x4d\x03\xff\x7b\x31\x14\x65\x4b\x8a\x5d\xef\x25\x31\x16\x20\x77\xf8\xe6\x71\xf8\x66\x38\x54\x9a\x82\x15\x07\x84\x48\x89\x16\xa3\x0d\x44\xad\x90\x0d\x8d\x56\x56\x4a\xb8\xde\x78\x63\x89\xb6\xf8\xc7\xa2\x2a\xe9\x1f\x34\x46\x9b\x08\xa4\x82\x4e\x5b\x07\x4f\xda\xb4\xc9\x2a\x4d\x41\x1b\x59\x49\x25\x1a\xd8\x7f\x82\x9f\x95\x56\x9f\x5a\xdd\x5b\x82\xd9\x5a\xaa\xf7\xbf\x3d\xd8\xde\xc3\x64\xdb\x19\x7d\xc0\x92\x60\xb6\x96\x46\xef\x75\x6f\x6b\xe9\xd7\x1a\x59\xa0\x2a\x86\xb5\xae\xdf\x37\xb2\x80\x52\xb7\x42\xaa\x64\xb5\x22\x96\x0a\x9d\xed\x13\xa9\xa4\x93\xa2\x91\xff\x22\x7f\xea\x55\xe1\xa4\x56\x3c\x86\xcf\x2b\x00\x00\xf9\x04\xbc\xd4\x45\xdf\xa2\x72\x49\xa1\xf5\x07\x89\x89\x45\x61\x8a\x9a\xa7\xb6\xca\xef\xd2\x18\xde\xe5\xb0\x1d\xe1\xf4\x6b\xb4\x28\x79\x9c\xf9\xff\x8f\x2b\x3f\x8c\xb4\x61\x6d\x02\x3e\x08\xe3\x05\x2b\x21\x87\x4e\x18\x8b\xcb\xdd\x02\x11\xfd\x5e\x71\xf6\x5d\xa9\x49\x24\x16\x27\xc2\x39\xc3\x59\x51\x63\xf1\x01\x4b\xb6\x01\xee\x59\x92\x61\x1d\xf2\x1c\x98\x33\x3d\xb2\x78\xe1\xef\xc5\xbe\xee\xee\x97\xaf\x7b\x53\x52\x59\x9c\x1c\x44\x13\xf0\x64\x58\x60\x28\xe1\x33\x0c\x19\x16\x98\x4e\x58\xfb\xb1\x9c\xa1\xa8\x3e\xe2\x6c\x35\x87\x69\xeb\x84\x71\xb2\x68\x68\xd7\x27\xa9\x4a\xce\x12\x32\x7e\x2f\xca\x83\xa0\xc4\xb2\x38\x41\x51\xd4\xa7\xbc\x01\x97\x1b\xc0\x06\x49\xc0\xa9\xcc\xf4\x0b\x66\xc8\xe1\x15\x1f\x21\xd9\x0c\x41\xc9\x3e\x9f\x0b\x5e\xbf\x1e\x7d\xc2\xde\xe3\xf9\x1b\x54\x95\xab\xe1\xdd\x3c\xef\x8b\x7d\x12\x83\xad\x3e\xe0\x2f\x8d\xb0\x96\xb3\x45\xd4\xf3\x7d\x8f\x57\xa2\x20\xe5\x2e\x44\x11\x14\xfe\x56\x51\x50\x66\x2e\x44\x71\xca\xe1\xd7\x8f\x63\xbe\xf3\xbd\xaf\xda\xbd\x7e\xbe\x57\xda\xf1\xfb\x50\xc3\xf1\x57\x8d\xe4\x78\xe5\x3e\x0f\x57\x36\xdc\xd4\xc5\xb5\x36\x48\xb5\xf6\xf9\x38\x29\xfd\xa1\x58\xc7\x36\xd2\x35\xd2\x71\x96\xb1\x78\x03\xb3\xfa\x3d\x88\x66\x19\x3f\xf1\xb9\xb6\x83\x9c\x16\x47\xcf\x7c\x19\xaf\x6b\xbb\xc7\xed\x0e\xf2\x30\x49\x0c\x76\x8d\x28\x90\xa7\x90\x56\x1b\x60\x4b\xb8\x41\xf7\x38\x20\xc9\xa7\xc4\x42\x97\xf8\xf7\x9f\xbf\x73\xb2\xdd\xed\x26\xe0\xe3\x64\x6e\xd0\xf5\x46\xd1\x70\x59\x11\xaa\x19\x1e\x7b\xdb\xf9\x08\x85\x56\x56\x37\x98\x34\xba\xe2\x11\x21\xa2\x09\x23\x9d\xcd\xdf\xb7\x1c\x4e\xbd\x8f\x1e\x01\xfb\x18\x4d\x1a\x40\xb4\x4b\x42\x02\xed\xe3\xf0\xb8\xec\xa8\x7b\xf4\x38\x27\xf2\x57\xe6\xe5\x44\xfe\x75\xba\x48\xe4\xab\xfe\xe5\x44\xc3\x6d\xb8\x4c\x35\xf6\xe4\x17\x93\x0d\x0e\xd1\x2e\x09\xb5\x0d\x6b\x60\x6c\xce\x19\x1a\xf5\x8b\x29\x87\x47\xf6\x1a\x63\x90\x1f\xd5\x58\x02\x8b\xbe\x1e\x54\x3d\xaf\x2f\x7a\x7a\x10\xeb\xbc\x1e\xba\xf9\xb8\x7e\x52\xe0\x8c\x18\x4c\x13\xcc\x78\xa2\x33\xc4\x5b\xa6\x6f\x82\x3f\xf8\x33\xdd\x02\x85\x1f\xe1\x57\xe1\x90\x4f\x09\x9e\xbb\xc4\xa2\x7b\x90\x2d\x72\x9a\x57\x61\x1e\xaf\xef\xb6\xdb\xed\x9b\x9f\xfc\xdf\xdb\x1f\xdf\xfc\xf0\x76\xee\x04\x39\x44\xf8\xdc\x49\x83\x36\x8f\x60\xed\x79\x9c\x7e\xff\xc7\xc3\x5f\xce\x48\x55\xf1\x18\xd6\x10\x65\xd1\x5c\xff\x4e\xb8\x9a\x1c\x69\xcc\x53\x57\x1b\x14\x65\x52\x54\x32\xcd\xa2\x79\x19\x55\x90\x03\xa3\x2f\x85\x8c\x4d\x4e\x12\x04\x67\x34\xe6\x0c\xd6\x83\x61\x0d\x2c\x63\x5f\xa8\xce\x68\xf4\x20\x6f\x58\x80\x82\xf4\x8c\x46\x0f\xf2\x86\x05\xe8\xa4\x3f\x1b\x66\x1e\x18\x8c\x0b\xe8\x98\x06\xe6\x27\x1e\x38\x98\x02\x6e\xc2\x39\xfb\x4e\x81\x9c\xce\xea\xd5\xcb\xfe\x07\xe3\x75\xbb\x89\x0a\x6a\xdc\x40\x05\x39\x6e\xa0\x82\x1e\x37\x50\x27\x31\x6e\xe0\x46\x2d\x4e\xb0\xd0\xff\xbe\xfc\x5c\xb1\xfd\xbe\x95\xce\xbf\xa1\x71\xb6\xa2\x16\xfa\x5f\x00\x00\x00\xff\xff\x21\x9e\xf4\xc8\x1c\x0b\x00\x00")
 
 func www40recformJsBytes() ([]byte, error) {
@@ -978,7 +1025,7 @@ func fileInitnodeTxt() (*asset, error) {
 	return a, nil
 }
 
-var _fileMessageEnTxt = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x9c\x56\x51\x6f\xe4\xb8\x0d\x7e\xd7\xaf\x20\x36\xe8\x36\x0b\x74\x9d\x34\xdd\x7b\xe9\xaa\x2a\x32\x59\xef\x6e\x70\xb9\x49\x30\x9e\xc3\x76\x51\x14\x86\xc6\xa6\x6d\x35\xb2\xe8\x93\xe4\x38\xbe\x5f\x5f\x50\xf6\x4c\x82\x3b\xe0\x1e\xfa\x90\x50\x22\x29\x91\x32\x3f\x7e\x9c\x33\x71\x06\x3f\x61\x08\xba\x45\x68\x8c\x45\x68\xc8\x43\xee\x5a\x6b\x42\x27\xce\xe0\x86\x86\xd9\x9b\xb6\x8b\x70\x5e\xbd\x83\xab\xcb\xcb\x1f\xde\x5f\x5d\xfe\xf5\x07\x08\x9d\x71\x5f\xf2\x7d\x18\xe1\xc1\xd3\x7f\xb1\x8a\x99\x38\x13\xc2\x6a\xd7\x4a\x85\x4e\x88\x33\xe8\xd1\x8d\x70\xd0\x5e\x44\x1a\xa4\xda\xdf\x3f\x08\x87\x93\x54\xdb\xfc\x9b\x30\xae\xc6\x67\xa9\x6e\xb7\x9f\xf2\x7f\x89\xaa\xd3\xae\xc5\x20\xd5\xcd\xd7\xeb\xed\x97\xbc\x10\x1e\x2b\x74\x51\xaa\x5d\x7e\x93\x6f\xf7\x22\xa0\xf6\x55\x27\x55\x91\x5f\xef\x6e\xbe\x8a\x9e\xd7\x57\x37\x5f\xdf\x6f\x76\xf7\xdf\x8a\x7c\x27\x7c\x08\x52\xed\x8a\x82\x63\xd6\x18\x2a\x6f\x86\x68\xc8\x09\x5e\x97\xc7\x48\x2c\x80\x1a\xd0\x55\x87\x35\x6c\x36\x05\x9c\x07\xf2\x11\x6b\x38\xcc\xf0\x84\x96\x2a\x13\xe7\x77\xd9\x72\xe8\x94\xd1\x1f\x1f\x8b\xa6\xc7\x10\x75\x3f\x1c\xcf\x9d\x12\x4f\xd2\xce\x30\x0e\xb5\x8e\xcb\xc1\xd5\xe5\xf4\x98\x24\xa1\xf1\xd4\x43\x75\xba\x7d\x75\x42\xef\xc9\x4b\xb5\x27\x08\xfa\x09\x41\x3b\x72\x73\x6f\xe2\x9c\xc1\x7e\xf4\x0e\xa8\x69\x52\x91\x2a\x72\x01\xab\x31\x9a\x27\x84\x81\x42\x3c\x66\x4f\x7d\xbf\xa6\xa1\x03\x39\x88\x04\x1e\x7b\x7a\x42\x38\x37\x0d\xcc\x34\x42\x40\x57\xb3\x9a\x62\x87\x1e\x1c\xd5\x18\xde\x9d\xf2\x73\xb5\x54\x2f\x61\x8c\x0f\x31\x5d\x9e\x22\x3a\x9c\xd2\x47\x98\x3a\x74\xe9\xa6\x49\xbb\xc8\x37\xa5\x3c\x67\x1a\xfd\xab\x64\xb9\x1e\x91\x06\x18\x74\x8b\xc2\x52\x4b\x52\x9d\x40\x23\x5e\x15\x4a\xaa\x87\xab\x87\xf5\x1c\x8d\x81\x03\x88\x60\x22\x4a\x75\xdf\x34\xa6\x32\xda\x42\x61\x22\x8a\x10\x75\x1c\x83\x54\x45\x92\x42\xb7\x1e\x71\x79\xe8\xf5\x71\x29\xa2\x89\x16\xa5\xda\xb3\x58\x71\xf4\x52\xcd\xa5\x2c\x70\xb3\xec\x85\xb6\x56\xaa\x6b\x6b\x19\x51\x65\xa5\x23\xb6\xe4\x4d\xc2\xe1\x69\x9d\x1e\x7d\x55\x75\x30\x06\xf4\xa0\x5b\x74\x31\xf0\xb3\x12\xaa\x44\x63\x6c\x44\x2f\xd5\xe7\x24\x85\xc7\x16\x9f\x07\x0e\xd3\xe6\xcf\x83\x88\xba\x95\x6a\xaf\x5b\x11\xa2\x37\xdc\x15\x45\x92\xac\x2f\xf9\xf5\x8c\xae\x61\x8c\x10\x75\x1b\x20\x0c\xd6\xc4\x68\x5c\xcb\xb8\x0a\x83\xae\x30\x83\x4f\x04\x8e\x22\x87\x86\xb7\x36\x7e\xfc\x0b\xbc\x6d\xf9\xbf\x76\x35\xbc\xd5\xfd\xf0\x31\x13\xa1\xa3\x89\x3f\x2a\x4d\x9c\x14\x57\x49\x2c\xf5\x2b\x7e\x5f\x60\xe1\x74\x8f\x52\x6d\x75\x8f\xa2\xd7\xc6\x4a\x95\xbf\x67\x29\x82\x69\x9d\x8e\xa3\x47\xa9\x8a\xe3\x52\xe8\x18\x35\xc3\xf4\x3a\x49\x11\xc6\xa6\x31\xcf\x52\x15\x49\x8a\x15\x9f\x39\x0b\x30\xee\xa5\x11\xc4\x09\x7b\x37\xcb\x42\x70\x52\x52\x3d\xdc\x17\xfb\xb4\x2c\x0f\x54\xcf\x52\x3d\x30\xa0\x22\x3e\x47\xce\x5b\xd7\xbd\xe1\x6e\xb5\x25\xd3\x8f\x54\x9f\xf2\xbb\x7c\x9f\x27\x18\xb0\xd2\x63\x45\xbe\x3e\xa9\xaf\x77\xfb\xdb\x9b\xbb\x5c\x2c\x90\x96\x6a\x91\xa2\xd2\xae\x42\x2b\xd5\x22\x57\xce\x28\x1d\x4e\xeb\xa5\x6b\xbf\x25\xe0\xf6\xfa\x11\x8f\x50\x16\x95\x47\xcd\x58\x5b\x64\xc2\x6c\xe7\x51\xd7\xe2\x04\x48\xa9\x4e\x4b\x61\x75\x88\xa5\xf6\xd1\x54\x7c\xe9\x17\xe2\x8f\x1c\x3b\x04\xd6\xc3\xaa\xcf\x98\xf0\x4a\x6a\x4a\x06\x3e\x77\xf1\xc0\x0c\x12\x3b\x13\x52\x2b\x64\xe2\x40\x31\x52\xff\xe2\xb1\x49\xfb\xdf\x38\xa5\x48\x8b\x9d\xab\xcf\x7f\xac\x62\x0e\xfd\x8d\xda\xe1\x24\xc8\xd6\xab\x96\x6c\xcd\x38\xe1\x3f\x81\xb5\x89\x65\xc2\x61\x5e\x9b\x05\x69\xc2\x2f\x9d\x10\x44\x98\x5d\x55\x32\xff\x94\x0e\xe3\x44\xfe\x51\xaa\x62\x76\xd5\xf1\x15\x61\xe1\xa6\xd5\x26\x9e\x4c\x8d\xc4\xc4\x24\xd5\x77\x6e\xf3\x83\xa7\x89\x7b\xa2\x26\x0c\x09\xa6\x61\x1c\x06\xf2\x31\x7d\x8d\xe4\xcc\xe1\xb2\x85\x93\x2d\x46\x7c\x55\xcb\xf2\x17\xa9\x3e\x51\xe2\x8f\xc5\x06\x0d\x59\x4b\x13\xc3\x7f\x8d\x7e\x1e\xde\xfd\xf3\x04\x89\x3f\xf2\xdf\x6c\x8a\x73\x64\xe7\x99\xdf\xf5\x3d\x4f\x53\x20\xe1\x53\x38\x3a\x61\xc7\x11\x84\xb1\xea\x8e\xb7\xb3\x69\x81\xc5\xd1\xc0\x48\x70\xa3\xb5\x2f\xb5\xdd\x8e\xd6\xc2\xf5\xd1\x9f\x4d\x2b\xb7\x24\xc3\x42\x30\x07\x5d\x1f\xb5\x1b\x5d\x2f\xca\x0c\xbe\xd3\x08\x95\x76\x7f\x5e\x5a\xf7\xcd\xc5\xbf\xff\x93\x2a\xd5\xc6\x8f\x6f\x12\x9f\x68\x48\x67\xb2\xf5\xd6\x79\x38\x5d\x3a\x0f\x28\x0e\xa6\x5d\x73\xdb\x13\xc1\xc1\xb4\x69\x28\x8b\x0f\x97\x7f\x93\xea\x33\xf9\x83\xa9\x6b\x74\xbc\x5d\x5b\x89\xa3\xd5\xc4\xd1\x3a\xe6\xe0\x01\x7d\x6f\x42\x30\x0b\xef\xeb\xaa\xc2\x10\x16\x58\xfd\xbc\xbb\xcd\xe0\xd6\x85\xa8\xad\x05\xa9\xa1\xf3\xd8\xfc\xe3\x4d\x17\xe3\xf0\xf7\x8b\x8b\x69\x9a\x32\x26\xe7\x16\x63\x18\x33\xe3\x1a\xba\x78\xf3\xc2\xd6\xf2\x42\xab\x4c\x7c\xb8\xfc\x20\xd5\x96\x22\x7c\xa6\xd1\xd5\xbc\x5d\x53\xd8\x77\x08\x1e\x7f\x19\x31\xf0\xac\xfb\x79\x77\x0b\x93\x5e\x40\xd1\xb0\x27\x70\x2e\x9c\x41\x40\xff\x84\x3e\x83\xbd\x9f\xc1\xea\x88\x1e\x12\x7d\xfc\xff\x19\x39\x2a\x6b\x1d\xf5\xf2\xf5\xb5\x6f\x47\xa6\x9c\xc0\xb7\x6e\x09\xd8\x92\x89\x30\xe8\x7e\x85\x6c\x9a\x62\x96\xe8\x31\x80\x35\x8f\x08\x9a\x89\xb6\xcf\x56\xde\x3e\x0e\xdd\x1d\xb6\xa3\xd5\x1e\xf0\x79\xf0\x98\x3e\x64\x58\xf0\x94\x09\xec\x87\x38\x97\xd6\x30\xa3\x6d\x89\x31\x83\x01\x66\x8c\x19\x7c\xd3\x26\x82\x86\x06\x27\xe8\x8d\x1b\x23\x86\x44\xd3\x95\x35\xd5\x23\xfc\x29\xa4\x36\x58\xc6\x97\xb0\xc6\x3d\x62\x5d\x26\x4e\x96\xea\x2e\xed\x60\x9b\x18\xfa\xd1\xd1\xe4\x8e\x96\x1f\x79\xb3\x1a\x18\x01\x41\xaa\x14\x50\x2c\x98\x0e\x52\xad\xe0\x0c\x22\xfd\x7e\x28\x83\xf9\x15\x79\x76\x55\x1d\x42\x61\x7e\x45\x11\xd0\x36\xe9\x36\x66\x3f\xdb\xa4\x31\x90\x7e\x97\x99\x50\x89\x96\xa8\x4d\x14\x76\x7f\xff\xe5\x2e\x17\xd6\xf4\x26\x4a\x95\x84\xe8\x0f\x52\xfd\xb4\x11\x8f\x07\xa9\x7e\xdc\xf0\x98\xa4\xaa\xec\xb1\x97\x2a\x2d\xd3\x0f\x9a\x1e\x7b\xf2\xb3\x88\x14\xb5\x7d\xe5\x90\xf6\xf0\x3b\xb7\x8a\x9c\xc3\x8a\x67\x7d\x79\x1c\xe2\x2f\x2a\xc1\xb4\x71\x99\x98\x9b\x21\x93\xaa\x54\x8f\x98\xc6\x97\xc3\xf7\x93\x9e\xe1\x95\xb3\x47\xab\x67\xac\xa5\x1a\x03\xb7\x7f\xda\xae\xc0\x12\x34\xa0\x63\x53\xc3\xcd\xf4\xea\x4c\x6d\xc2\xba\x63\xeb\xeb\x9d\xf8\x5f\x00\x00\x00\xff\xff\x8b\x55\xca\x25\xfd\x0a\x00\x00")
+var _fileMessageEnTxt = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x9d\x57\x6d\x93\xdb\xb6\x11\xfe\x8e\x5f\x81\xb1\x27\xee\xdd\x4c\xcc\xbb\x5e\x93\x2f\x89\xaa\xce\xbd\xc8\xf6\x4d\x1c\xdd\x45\x92\xc7\xcd\x74\x3a\x1c\x88\x84\x48\xf4\x48\x80\x01\x40\xeb\x94\x5f\xdf\x67\x17\x24\xa5\x73\x3a\xf9\xd0\x0f\x12\x16\xef\x8b\xdd\x67\x9f\x5d\xbe\x16\xaf\xe5\xcf\x3a\x04\x55\x69\xb9\x33\x0d\xfe\x9c\x97\x0b\x5b\x35\x26\xd4\x98\xba\x75\xdd\xc1\x9b\xaa\x8e\xf2\xac\x38\x97\x57\x97\x97\xdf\xbf\xbd\xba\xfc\xeb\xf7\x32\xd4\xc6\xbe\x5f\x6c\x42\x2f\x1f\xbd\xfb\x8f\x2e\x62\x26\x5e\x0b\xd1\x28\x5b\xcd\xe6\xda\x0a\xec\x6c\xb5\xed\xe5\x56\x79\x11\x5d\x37\x9b\x6f\x1e\x1e\x85\xd5\xfb\xd9\x7c\xb9\xf8\x2c\x8c\x2d\xf5\xf3\x6c\x7e\xbf\xbc\x5b\xfc\x53\x14\x35\x36\xe9\x30\x9b\xdf\x7e\xb8\x5e\xbe\x5f\xac\x85\xd7\x85\xb6\x71\x36\x5f\x2d\x6e\x17\xcb\x8d\x08\x5a\xf9\xa2\x9e\xcd\xd7\x8b\xeb\xd5\xed\x07\xd1\x92\x7c\x75\xfb\xe1\xed\xcd\xea\xe1\xf3\x7a\xb1\x12\x3e\x60\xef\x6a\xbd\xa6\x3b\x4b\x1d\x0a\x6f\xba\x68\x9c\x15\x24\xe7\xe3\x4d\xd4\x48\xb7\x93\xaa\xa8\x75\x29\x6f\x6e\xd6\xf2\x2c\x38\x1f\x21\x6f\x0f\xf2\x8b\x6e\x5c\x61\xe2\xe1\x3c\x4b\x9b\x26\x8d\xfe\x7c\x5b\x34\xad\x0e\x51\xb5\xdd\xb8\x6f\x52\x9c\xdb\xe6\x20\xfb\xae\x54\x31\x6d\x1c\x96\x4c\x8f\xe1\x56\xee\xbc\x6b\x65\x31\x9d\x3e\x2c\xd2\xde\x3b\x0f\x93\x39\x19\xd4\x17\x2d\x95\x75\xf6\xd0\x42\xbf\x4c\x6e\x7a\x6f\xa1\xcf\x8e\x9d\x54\x38\x1b\x74\xd1\x47\x83\x35\x9d\x0b\x71\xd4\xde\xb5\xed\xa0\x86\x0a\xce\xca\xe8\xa4\xd7\xad\xc3\xa2\x33\xb3\x93\x07\xd7\xcb\xa0\x6d\x49\xc3\x2e\xd6\xda\x4b\xeb\xb0\xed\x7c\xd2\xcf\x96\xb8\x79\xba\xc6\xf8\x10\xf9\x70\xbe\x11\x0e\x64\x23\xec\x6b\x6d\xf9\xa4\xbd\xb2\x91\x4e\x62\x3d\x31\xe0\x4f\x94\x25\x7f\xc0\xf5\xb2\x03\xb2\x44\xe3\x2a\x37\x9b\x4f\xa0\x11\x27\x8e\x9a\xcd\x1f\xaf\x1e\x87\x7d\xae\x0f\x74\x81\x08\x26\xea\xd9\xfc\x61\xb7\x33\x85\x51\x8d\x5c\xa3\x2b\x60\xea\xd8\xc3\x29\x6b\x6e\x85\xaa\xbc\xd6\xe9\xa1\xd7\xa3\x28\xa2\x89\x0d\x36\x6e\xa8\x19\x70\x74\xf4\x66\x72\x8b\xbc\x4d\x7d\xa1\x9a\x06\x5b\x9b\x86\x10\x95\x17\xf0\x53\xe5\xbc\x61\x1c\x4e\x32\x3f\xfa\x0a\x7e\xea\x03\x0c\x85\x77\xd8\x18\xe8\x59\x8c\x2a\x81\x68\x89\x1a\x7e\x7a\xc7\x2d\xae\xab\xf4\x73\x47\xd7\x54\x8b\xe7\x6e\x80\x6d\xbe\x77\xbe\xc4\x99\x9f\xa9\x39\x85\xc0\x8b\x09\x78\xa4\x53\x5e\x0d\xb8\x0a\x9d\x2a\x70\xb7\xf2\x70\x7d\xd3\xc8\xb4\x5e\x97\x99\xfc\x14\xb4\x7c\x95\x65\xd9\x2b\xd6\x4b\xc9\xae\xf6\x2a\x10\x3e\x4a\xf9\xb0\xe2\x31\x6d\xd8\xa5\x40\x2c\x1f\x9f\x8d\x5a\xd4\x26\xe2\xae\x6f\x4a\x49\x82\x88\x0a\x41\xba\x51\x15\x4c\xea\x0d\x05\xec\x9a\x5b\x1a\xcf\x49\x45\x02\x7e\xd7\xc3\xb1\xaa\x82\x6a\x5d\x63\x62\xc4\xf4\xa4\x5a\x26\xef\x1c\x50\x13\xc9\x2a\xf2\x4d\x13\x7f\xfc\x56\xbe\xa9\xe8\x9f\x14\x79\x83\x78\xf8\x11\xf7\xd6\x6e\x4f\xfe\x76\x7b\xb2\x17\x01\x48\x24\x68\xad\xff\x88\x3d\x61\x55\x0b\xa7\x2d\xf1\x2f\x5a\x65\xe0\x95\xc5\x5b\x6a\x81\x82\xca\xc2\xd7\x1e\x93\xeb\x51\x14\x78\x66\xa5\xcb\x9c\xe6\x4e\x86\xa5\x61\x67\x61\x46\xa8\x18\x15\x85\xd8\x35\xb7\x22\xf4\x40\x11\x28\x60\xcd\xad\x18\x62\x6b\x41\x0d\xfc\x78\x0c\x62\x31\xc5\xcd\x6d\x12\x04\x69\x0d\x70\x3e\xac\x37\x2c\xe6\x5b\x57\x1e\xd0\xa7\x60\x88\xfa\x39\xd2\xc3\x54\xd9\x1a\x62\x9a\x26\x27\xea\x9c\xcd\xef\x16\x1f\x17\x9b\x05\x43\x98\x06\x81\x40\xb8\x61\x1a\xbe\x5e\x6d\xee\x6f\x3f\x2e\x44\x0a\xc7\xd9\x3c\xb5\xa2\x50\xb6\xd0\x78\x75\x6a\x47\x97\x21\xd6\x86\x43\x07\xae\xe0\xa0\x6b\xd5\x93\x1e\xc3\x50\x14\x5e\x2b\x8a\x93\xd4\x72\xbc\xd5\x10\x61\x82\x31\x98\x66\xf3\x49\x04\x37\xe3\x0d\xca\x47\x53\xd0\xa1\xef\x1d\x79\x01\x4e\x90\x34\x2e\x87\xf1\x8c\xc8\x3a\x77\xbb\x9c\x82\x96\x18\xa8\x23\x2c\xc5\x1a\xd6\xa5\x91\x4c\x6c\x5d\x8c\xae\x3d\xae\xb8\xe1\xfe\x57\x8b\xf8\xa6\x34\x4f\xf0\xa0\x1f\x0d\x11\xff\x7f\x35\x8c\x11\xe1\x9a\x72\x18\x85\x44\x40\xa2\x9f\xd0\xa5\x89\x39\x03\x75\x01\x89\xa1\x08\xbb\x71\x14\x07\x11\x0e\xb6\xc8\x89\x3b\x61\xa5\x08\xa4\x3f\xc1\x48\x18\x1a\x5f\x11\x12\xaf\x0e\x73\xe2\x8b\x29\xb5\x23\x52\x9d\xcd\x7f\x25\x8a\xda\x7a\xb7\xa7\x78\x2e\x1d\x56\x12\x8e\x43\xdf\x75\x60\x75\xb6\x06\x2f\xa6\xeb\xb2\x94\x4f\x1a\x0d\xcb\x1e\x7d\x99\xff\x06\x6f\x3a\xe6\xbe\x34\x07\xd4\x35\x8d\xdb\x53\x7c\x0c\xb7\x9f\x85\xf3\x7f\x4c\x90\xf8\xb3\xf5\x70\xe1\x99\xa6\xc5\x07\x7a\xd7\xaf\x0b\xce\x60\x8c\x4f\x61\xdd\x84\x1d\x0b\x76\xed\xe1\xfe\xe1\x74\x9a\x4a\xb0\x18\x27\x08\x09\xb6\x6f\x9a\xa3\x6f\x97\xe8\xc9\xeb\x71\x3d\x4d\x0d\xbc\xc8\x13\x89\x1c\xb7\xaa\x1c\x47\x6f\x54\x99\x06\x33\x09\xfb\x20\x1d\xd9\xbf\xa4\xd8\x7e\x75\xf1\xaf\x7f\xb3\xa7\xe0\x90\x91\x73\x78\x4f\x36\x9c\x7a\xe8\xa6\x43\x21\x8a\xad\xa9\x06\xdd\x36\xce\x49\xf4\xb8\xa0\x10\xdf\x5d\xfe\x0d\x24\xe9\xfc\xd6\x94\x25\x4a\x03\x74\x87\x50\xa2\xdb\x4a\x47\xb7\xd5\x94\x3f\x3a\xed\x5b\x13\x82\x49\x39\x4b\x15\x20\xc1\x90\x60\xf5\x69\x75\x9f\xc9\x7b\x8b\x38\xc5\x55\x33\x25\x81\xf2\xdd\xdf\x5f\xd5\x31\x76\x3f\x5c\x5c\xec\xf7\xfb\x8c\x12\x4b\xa5\x63\xe8\x33\x63\x77\xee\xe2\xd5\x31\xd3\xcc\x2e\xd4\x3c\xc3\x9d\xdf\x41\x51\xb8\xfa\x9d\xeb\x6d\x49\xdd\x41\x85\x0d\x5c\xee\xf5\x6f\x3d\x38\x00\xe4\x8b\x7b\x90\xd2\x12\x28\x76\xb4\x52\x92\x2e\xa4\x01\xf0\xf2\x45\x7b\x24\x60\x7f\x40\xc0\x44\xe2\x58\xa2\x8f\xff\x5f\x23\xb8\x11\xb5\x81\x4a\xd6\x57\xbe\xea\x89\x72\x02\x9d\xba\x74\x92\x66\xc0\xa1\x9d\x6a\x07\xc8\x72\x06\x6e\x9c\x7b\x0a\xb2\x31\x60\x00\x45\x4c\xdc\x66\x43\xce\x19\x0b\x06\x64\x9e\xbe\x51\xc8\x02\xcf\x1d\x42\x85\x0c\x19\x12\x9e\x32\xa1\xdb\x2e\x1e\x72\xd4\x74\x91\xec\x40\x98\x01\xf6\x0f\x3a\x66\xf2\xb3\x42\x78\x29\xb9\x03\xa7\x80\xcb\xfa\x48\x99\x07\x0f\x2f\x1a\x53\x3c\xc9\x6f\x02\x87\x41\x4a\xbd\xa2\x31\xf6\x09\xd4\xcb\xa4\x3d\x9b\x7f\xe4\x1e\xd4\x25\x0a\x7f\xb2\x6e\x6f\xc7\x99\x9f\xa8\x33\x4c\x10\x02\x30\xc4\x17\x8a\x84\x69\x74\x07\x70\x06\xc1\xb5\x0f\xc8\xfc\x77\x4d\x79\x17\x32\x72\xfd\xef\xc8\xf5\xba\xd9\xf1\x69\xc4\x7e\xcd\x8e\xf3\x04\x29\x92\x0a\x2a\x09\x7f\xf5\x5a\xa4\x4e\xce\x9d\xd9\xfc\x53\x9a\xfa\x85\xa7\x38\xd1\xbe\x9c\x87\xf7\x0d\xca\x09\x15\xd9\x2c\x20\x2c\x62\xd1\x41\xa1\xaf\x12\x52\x26\x3a\x64\x29\xce\x8c\x8f\x49\x10\x3b\xe4\x23\x8d\x68\x7c\xc7\x2d\x85\x37\xaa\x2e\x4f\x23\x77\xa3\x28\x92\xbe\xf4\x6c\x11\x53\x25\xb1\xa1\x06\xb4\xf7\x0c\x2a\xf3\x40\xdb\x12\x12\x41\x68\x50\xbd\xcc\x55\x1c\x15\x2f\x13\x6b\x9e\xa6\x27\x98\x0b\xbb\xf2\xf1\xea\x15\xf5\x64\x52\x40\xa6\x4d\x81\xef\xcc\x6b\xad\x9a\x58\xa7\xab\xe5\x07\xee\x24\x0b\xbc\x98\x05\x71\xf6\x4d\xe4\xa7\x03\xb5\x40\xd2\x1e\xc5\xc2\x8b\x67\x27\x97\xa5\xf1\x1a\x35\x3f\x26\x02\xec\x33\xd4\x22\xf0\x09\xca\x7c\xdc\xdd\x62\x08\xa7\x44\x6d\x81\x51\x9a\x87\x93\xa8\x11\x14\x18\xb6\xc0\x3b\x3f\x26\x01\xb9\x97\x83\x98\x4c\xb1\x1e\x45\x36\x65\xcf\x54\xfe\x6e\x90\xf0\x3d\x90\xab\xdc\x53\xc5\x80\x8c\xac\x24\x24\xd1\x79\x17\x5d\xe1\x9a\x64\x11\xaa\xc5\x9b\x46\x57\xa8\x05\xf1\x8c\x8e\x0a\x5f\x94\x31\x5e\xd9\xb0\xc3\x3c\x59\xe7\xe6\x40\xe6\x60\x1b\x06\xad\x2d\xe9\x80\x90\x59\x6b\xfa\x16\x81\x1d\x11\x5c\x5c\x6a\xae\x46\x71\xac\xd8\x4f\x66\x12\x14\xf6\xb5\xa1\x64\x6b\x10\xd7\x5b\x3d\x54\xce\xa9\xdc\xaf\x29\xf3\xc2\x81\xd3\x26\xa4\xbb\x00\xab\x6d\x78\x62\x0f\x0a\xe1\x3a\x98\xd1\x3c\x21\x0b\x0f\xd2\xc0\xf5\x90\x99\xd9\x90\x4f\xba\xa3\x43\x2a\xe5\xcb\x86\x28\x8e\xbe\x2f\x28\x6d\xfa\xde\xbe\x54\x88\x8b\xf6\xa5\x4b\x29\x72\x8a\x9d\x87\xa6\x94\x53\xfc\x0c\xfa\x1d\x67\x57\x83\xc2\xd3\x0a\x4e\x25\xd3\xa1\x4b\x77\x8a\xf9\xe3\x03\x51\xfe\xab\x03\xb6\xd3\xbf\xe8\x6d\x63\x50\xc2\x93\x59\x27\x51\x88\x5d\x28\x90\x67\xef\x40\x4d\x5b\xaa\x3a\x6f\x6b\x5d\x3c\x25\x2b\xa6\x19\x1e\x08\xf2\xfe\x8e\x5f\x34\xdc\xf2\x2d\x04\xb8\x08\x68\xd0\x7c\x63\x2a\xce\xa0\x1f\xf3\x42\x2a\x19\xe9\x3b\x06\xc4\x44\x88\xa1\x9d\x44\x73\x7d\x07\x8c\x6e\x79\x05\x97\xde\x5c\x89\xd2\x5a\xa0\x1e\xf6\x3b\xb0\x5d\xc9\x21\xe5\xa0\x0e\x51\x61\xa7\x8c\xcf\x81\x1b\xec\x6b\xd9\x12\x34\xc0\x6a\xe7\x2f\xc3\x97\x4f\x02\xc7\x13\x45\x33\xdf\xa7\x4f\x84\x52\x6c\xfb\xe2\x49\x23\x24\x6f\xb8\x15\xb8\x08\x64\xa6\x0f\x62\x38\x14\x74\x90\x84\xe1\x32\xd2\x90\xaa\xb8\x51\x1e\x86\xe9\xa2\x51\x22\xf3\x1f\x55\x82\xf5\xc7\x8e\xdc\xc3\x2a\x29\xc9\x30\xc5\x22\xf3\x15\xa2\x72\xae\xe2\xe2\xec\xe1\xe1\x3d\xea\x44\x36\xfd\x6c\xce\x8d\x68\xb7\xb3\xf9\xcf\x37\xe2\x09\xcd\x4f\x37\xf4\xf1\xe2\x8a\xbc\x25\x9d\x58\xe4\x6f\x07\x74\x1d\xc8\x25\x3a\xc4\xf7\xc9\x02\xee\xcb\x3f\x2c\xeb\xbb\xc6\xa9\x92\x94\xfd\xc4\x92\x3c\x8b\x0e\xee\x97\x17\x29\xe3\xb5\xce\xc6\xfa\x5c\x94\xa0\xf2\x71\xdd\xdd\x20\xff\xef\x95\x70\xa3\x05\x41\x00\x66\xf9\xf8\xa9\x76\x1c\x12\x54\x60\x5d\x72\x8d\x4b\xc9\x95\xf3\x59\xd9\x6b\x26\x5e\xab\xdf\xee\x71\xda\xc9\x62\xaf\x1b\x75\x60\xf8\x05\x72\x11\x77\x87\x14\x2c\x1c\xb8\x99\xa6\x76\x54\x76\x9c\xec\x29\x61\xc0\xd4\xa3\xd9\xd3\x9e\xf8\x2f\x6e\x6c\x5d\xfc\xe3\x10\x00\x00")
 
 func fileMessageEnTxtBytes() ([]byte, error) {
 	return bindataRead(
@@ -993,12 +1040,12 @@ func fileMessageEnTxt() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "file/message-en.txt", size: 2813, mode: os.FileMode(420), modTime: time.Unix(1491401929, 0)}
+	info := bindataFileInfo{name: "file/message-en.txt", size: 4323, mode: os.FileMode(420), modTime: time.Unix(1792198231, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _fileMessageJaTxt = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xb4\x57\x4b\x73\xe3\xc6\xf1\xbf\xcf\xa7\x60\x59\xf5\xdf\xda\x3d\x78\x57\xff\xcd\xfa\x92\x45\x70\x70\xca\xe5\xaa\xa4\x5c\xb5\x15\xe7\x96\x4a\xa1\x20\x70\x44\xc1\x0b\x02\x0c\x00\x59\xab\x9c\x38\x03\x3d\xa8\x07\x45\xae\x56\x0f\xeb\x15\x49\x2b\x8a\xa2\x44\x8b\x94\xed\x7d\xe8\xb9\xfc\x30\x4d\x00\xe4\x29\x5f\x21\xd5\x03\x90\x22\x25\x96\x7d\x49\x4e\x7c\xcc\x4c\xf7\xaf\x7b\x7e\xfd\xeb\x9e\x21\x32\x94\xf8\x86\x3a\x8e\x9a\xa2\x89\x51\xdd\xa0\x89\x51\xcb\x4e\xfc\x49\xcd\xa8\x26\x75\x28\x19\x4a\xfc\xd1\xca\x4c\xda\x7a\x6a\xcc\x4d\x3c\xd4\x1e\x25\x9e\x0e\x0f\x7f\xf1\xf9\xd3\xe1\xff\xff\x22\xe1\x8c\xe9\xe6\xd7\x5f\xfd\xd5\x19\x4f\xbc\xb0\xad\xef\xa8\xe6\x3e\x26\x43\x84\x18\xaa\x99\x92\xe4\xef\x54\x42\x86\x12\x69\x6a\x8e\x27\x46\x54\x9b\xb8\x56\x46\x92\xc1\xcb\x81\xe7\x81\xb7\x4e\x4c\x3a\x21\xc9\xc1\xda\x59\xab\x5c\x68\xde\x6c\x07\xb9\x22\xd1\xcd\x24\x7d\x25\xc9\xcd\xf3\x6c\xab\x7c\x44\xb4\x31\xd5\x4c\x51\x47\x92\x83\xed\x6c\xf8\x9e\x07\x5b\xef\x82\xb5\x33\x62\x53\x8d\x9a\xae\x38\x18\xee\x64\x03\x6f\xda\xdf\xfb\x89\x38\x54\xb5\xb5\x31\x49\x0e\x4a\xdb\xe1\xbb\xb7\x24\x8d\xdf\x9f\x6a\x63\xe0\xad\x81\x77\x0c\xbc\x0c\xfc\x03\xb1\x1d\x47\x92\xff\xf2\xed\xb7\x08\xc9\xb5\x32\x89\x8c\x9a\xa2\xc4\xb0\x52\x96\xb0\x15\x6c\xe7\x48\x92\x3a\x9a\xad\x67\x5c\xdd\x32\x25\xf9\xc5\xd3\x17\xfe\x62\xc3\x2f\xe6\x83\xa5\x9f\xc3\xd2\x65\xb0\xd3\x20\x8e\xee\x52\x49\xf6\xa7\x7f\xf4\xaf\x0b\xc0\xdf\x03\x2f\x81\x97\x23\x8e\xab\xba\xe3\x8e\x24\x87\xf3\x1f\x82\xe9\x05\xa2\xa6\x6c\x4a\xd3\x02\x22\x78\x79\x11\x6a\x0e\xbc\x3a\x78\xd7\xc0\xeb\x7e\xee\x38\x5c\xa9\xb4\xca\x85\xf0\xdd\x14\x71\x75\xd7\xa0\x92\x0c\xbc\x11\x59\x02\xaf\x1a\x47\xa7\xf4\x86\xde\x6a\xbc\x06\x56\x8b\xa3\x57\x0d\x03\x11\x54\xda\x5e\x05\xa3\x54\x34\xd5\xa5\x29\xcb\xd6\x71\xaf\x7f\xc6\xa3\x80\xc3\x95\x0a\xf0\x2a\x78\x33\xc0\xdf\x81\x77\x02\xde\x35\xc6\xdc\x13\x9d\x88\x54\x89\xb3\x0d\xde\x2c\xf0\x03\xe0\x17\xc0\xeb\xc0\xaa\xcd\xc6\x8e\x7f\xfa\x03\xb0\x55\xe0\x8b\x90\x65\xad\xd9\x13\x7f\x61\x35\xdc\x9c\x02\x56\x8d\x30\xc4\x4b\x7c\xa1\x9b\x18\x60\xb5\xe8\xca\x1e\xf6\xc0\x3d\x07\x96\x6f\x7d\xba\x06\xd6\x08\x56\xcf\xda\x7b\x33\x8f\x22\xa7\xdd\xc8\xfe\xab\x6e\xc5\x8e\x60\x83\xfb\xb9\xab\x5b\x57\x5d\xa6\x08\x50\xbd\x88\x80\xd5\x80\x71\x60\x07\xc0\x76\xef\x9b\x8b\x4e\x77\x28\xf5\x6b\x38\x59\x19\xd8\x54\x3f\xa4\x05\xe0\x73\x31\x0b\x85\x19\x6a\xdb\x96\x2d\xc9\x31\x95\xb2\x47\xe8\xa5\xb1\x13\x2c\x32\x81\x61\x17\x38\x7e\x09\x8e\x77\x5b\xde\x0d\x64\x79\x3b\x7b\x10\x7e\xd8\x0c\xe6\x57\xc3\x4a\x03\xd8\x06\xf0\x05\x60\x15\x60\x79\x60\xf5\x70\x6a\xdf\x9f\xbf\x00\x56\x05\xb6\x2e\x1c\x17\x80\xed\x21\x0e\x36\x15\x67\xd6\x4a\x47\xb4\x6b\x5e\xad\xa1\x71\x6f\x09\x39\xe7\xcd\xe1\x11\xce\xdb\xd9\xcd\x70\xf7\xb0\xdf\x66\x15\x58\xdd\x9f\x9b\x6f\x6f\x94\x80\xd5\xc2\xe2\x4c\xb8\xf2\x13\xf0\x65\x91\xa8\xa9\x81\x2e\x1c\x6a\x26\x25\xb9\x37\x63\xc1\x76\xd6\xcf\xed\xdc\xb9\x70\x60\x47\x90\x65\xc0\x4e\x80\xcd\x63\x46\x58\xe9\x36\x7c\xbe\xdc\x6c\xec\x00\xdb\xc7\xd8\xd1\x4b\x84\x64\x0f\xd8\xeb\x5f\x0b\x90\x0c\x25\x04\x5b\xc9\xa8\x6e\xb8\xd4\xc6\x5b\x59\xc5\x5b\xf1\xaa\xc0\x1b\xc4\xa6\x29\xfa\x2a\x23\xc9\xc1\xe9\x41\xab\x5c\x68\xed\x57\xc2\xc2\x27\xe2\xaa\xa9\xb8\xb6\xce\x88\xe3\xda\x3a\xea\x51\xb0\x36\xeb\x9f\xae\xfb\xb9\x75\x5c\x55\x30\x24\xdc\x72\x01\xde\xa6\x28\xcf\x0b\x60\x47\xfe\xe2\xa5\x9f\x9b\x15\xd4\x28\x47\xa7\x81\x2f\xfb\xd3\x87\xfe\xfc\xd6\x7d\x5c\x90\xe5\x0f\x0c\xf7\xf9\x83\x94\xfb\xfc\x81\x9a\xce\x3c\x07\x56\x6f\xde\x34\x80\xe5\x80\x7d\x02\xb6\x05\xfc\x0d\x64\x39\x71\xc6\xac\x09\x49\x46\x58\xa5\x4b\x8c\x24\x63\x39\x2e\x89\x52\xf9\x9b\x57\x45\x4c\x35\x8d\x9a\x53\xcc\xfb\x73\x79\x92\x56\x75\x43\x92\xbf\xfa\x1c\x3f\x89\xa3\xa7\x4c\xd5\x1d\xb7\xa9\x24\x87\x37\x3f\xfb\xc5\x3c\x51\x5d\x57\x15\x2a\xf8\xf1\xaa\x79\xf5\x83\x48\xd1\xbe\x90\x96\x2a\x71\xc6\x47\x47\xf5\x57\x92\x1c\x2c\xec\xfb\xd7\xef\xfd\xd3\x22\x89\x89\xd9\x57\xa8\xa2\x80\x80\x55\x5b\x27\x25\xff\x63\x8d\x74\x19\x05\xfc\x17\xf0\xf6\xc1\xfb\x05\xf5\x0e\xe1\x4b\x72\xc4\x51\xf1\x43\x19\xb1\x92\x93\x58\x66\x3f\x06\x6b\xb3\x18\xa0\x9a\x4c\xeb\xa8\x31\x86\x82\x8d\xa4\x9f\x30\x11\xdf\xc4\xa2\x4d\x35\xcb\x4e\xf6\x43\xb8\xdd\x61\xd3\xb4\xf5\x3d\x86\x1e\xfd\xd4\x54\x53\xa3\x06\x42\x39\x05\xef\x00\xa1\xf0\x2b\x11\x98\x28\x53\xc5\xa4\x13\x1d\x67\x28\x15\xeb\xc0\xa6\x6e\xbd\xf2\xe5\xe6\xcd\x76\x2f\xef\xa3\x02\x8d\x33\xac\xd9\x54\x75\xe9\x9d\x4e\x84\x3d\x62\xcc\xa6\x6a\x92\xa8\xa6\x65\x4e\xa6\x2d\x54\x78\xbf\x98\x0f\xa7\xf6\x85\xf5\x55\xe0\x6f\x88\xa1\x3a\xae\xa2\xda\xae\xae\x09\xc7\xdb\x59\xe1\xbb\xaf\x14\xb0\xe7\x29\xd6\xa8\x82\xcd\x06\x59\x1b\x11\xed\x1c\xc3\x9c\xce\xb5\xf7\x4e\xc9\x88\xe5\xba\x56\x7a\xf0\x96\xe6\xf9\x02\xb1\x51\x2b\x5b\x8d\x95\x66\x63\x9f\x38\x19\x44\x14\x5d\x62\xe9\x88\xd8\x34\x39\xae\xe1\xed\x9f\xd7\xfc\xb3\x42\x84\x26\x32\x22\x48\x69\xb8\xcf\x23\x48\xd8\x68\xef\x2e\xac\x9d\x11\xcb\x48\xc6\xff\xfa\x85\x92\xa0\x70\xca\x7d\x4e\x68\x52\x77\x95\x9e\xda\x01\xbe\x1c\x7e\xac\xb4\xb7\x66\xe2\x6c\x39\x93\xa6\xa6\x8c\xda\x56\x5a\x31\xa9\x3b\x61\xd9\x2f\x07\xb5\xb9\x48\x06\x51\x39\xf1\x27\x5e\x80\x5f\x5c\x0c\xb6\x77\x63\x1b\xdf\xeb\x49\x6a\xa1\x32\x4a\x32\x8a\xfc\xca\x15\x6e\x98\xc9\x87\x2b\xbb\x1d\x7d\x42\x65\x12\xbb\xba\x20\xb0\xdf\x7a\x3b\xa2\x4c\x72\xe2\x06\x76\x7b\x9b\x3b\xb0\x45\xbf\x31\xdd\x2a\x33\x94\x1e\xb6\x11\xb5\x3b\x83\xba\x94\x4c\x8a\x5e\xc3\xea\x91\x86\x75\x48\xa7\xfc\x43\xf0\xd5\xbf\x79\x23\x7c\xbd\x01\x56\x7b\x88\x1f\xd8\x60\xe6\x80\xd5\x1e\xf5\x71\x92\x2f\x77\x54\x72\x5d\x14\xf6\x06\xb0\x85\x7f\x5f\xef\x76\x19\xfe\xdb\xd6\x7a\xa8\x38\xd8\x14\x19\x4a\x88\x82\x24\xa6\xd5\xad\x0b\x60\x28\xac\xc0\x73\xc0\x66\x80\x9d\xf4\x97\x49\x1d\x1b\x18\x9f\xef\x13\x1a\xd3\x8a\x6b\xe0\xee\xc9\xdb\xfa\x1b\x78\x6c\xdc\x30\x7a\x68\x7c\xa7\x1a\x67\xa6\xfd\xda\x05\xb0\xc5\xf0\xf8\x32\x4a\x6e\xf7\xc8\x80\xf9\xe5\xee\xbe\x11\x35\x39\x78\x5b\x15\xb2\x8b\x4f\xfe\xf6\xf7\x8e\x7a\x42\x36\x2f\xfa\xe1\xb1\xe8\xaa\x0b\x78\x9d\xc5\x2a\x82\xec\x36\xd9\x28\x59\x59\xde\x93\xd7\xfa\x5d\x93\x03\xd5\x37\x82\x3a\x99\xc1\x42\xa9\xd4\xda\xfb\xff\xba\x87\x51\x4f\x75\xd2\xd6\xa3\x98\x08\xa1\x74\x24\xe4\x62\x03\xd8\x52\xd7\x3f\x79\x36\xfc\x3b\x49\x0e\xab\x0b\xfe\xf4\x61\x58\x66\xc1\xe9\xdb\xf8\xcf\x58\x05\xfb\x6c\xf0\xe5\x48\xf5\x23\x5a\x07\x95\xe3\xf6\x46\x11\xd8\xe2\xfd\x3b\x90\xd4\xc4\x98\x4d\x47\xff\xf0\xd9\x98\xeb\x66\x7e\xff\xe4\xc9\xc4\xc4\xc4\x63\x1c\xac\x53\xd4\x75\xc6\x1f\xeb\xe6\xa8\xf5\xe4\xb3\x78\x4a\x95\x9e\xa8\xb2\xa8\x87\x92\x10\xc1\x0b\x11\xfe\xb5\x40\x3c\xa0\x3d\x91\x67\xc3\xcf\xee\x05\x26\x6e\xb6\x24\x8a\xb4\x9f\x09\xcf\x86\x9f\x75\xc4\x7c\x83\xb7\xd7\xde\xa0\x1f\x6c\xe1\x38\x0d\xb4\x8e\xcb\x1d\x0f\x8d\xfb\x7e\x84\x99\x5d\x60\xf5\xff\x5d\x24\xa6\xa5\x24\x55\x57\x95\x64\xff\x7a\x35\x58\x3d\x03\xb6\x18\x9c\x1e\x88\xad\x05\x31\x6a\x4c\x61\x40\x59\x76\xab\x3a\x83\x12\x4d\x9c\x8c\x9a\x8e\x9b\xfe\x6b\xf0\xf6\x44\x4b\x68\x88\xf3\xd1\x44\xf7\x29\x16\x97\x2c\x8f\xc7\x8a\xce\x04\xd7\x3b\x5c\x88\xe2\xaa\xa0\xfa\x78\xd7\xb7\x44\xa2\xe9\x8c\x3b\xa9\x18\x3a\xb6\x47\x61\x68\xaf\xa7\xf0\x06\x60\x11\x9e\xce\x04\x95\x0b\xfe\xa7\xe9\x78\xe6\x10\xd2\xf9\x7f\x8e\x48\x4c\x5d\x4c\xef\x9e\x50\xd4\x41\x29\x21\x43\x89\xe8\xf5\x41\x0c\xdd\x7c\x49\x93\x8a\x69\x25\x51\xef\xda\x9b\x07\xc1\xd2\x61\x77\xac\x20\x2f\x4d\x6b\xc2\xec\x2c\x06\x4b\x6f\xb1\xf9\x75\x17\x91\xfb\xce\x9d\xa9\x6e\x55\xbc\xb3\x2c\x3b\xe9\xdc\x13\x04\x5c\xd3\x54\x6d\x8c\x2a\x8e\xfe\x4f\x7a\xdb\x90\x3d\xe0\x1f\xc1\x3b\x8c\xdf\x45\xfc\x92\x38\xd4\x18\x15\x3e\x25\x19\xa7\xf9\xdc\x4c\x6b\xf6\xa4\x75\x59\xed\x9d\x77\xc4\xeb\x50\x77\x34\x62\xe8\x69\x3d\x9a\xd4\xb1\xb1\xa5\x47\x24\xf9\x9b\x2f\xc9\xcb\x11\x49\xfe\xf3\x97\x24\x65\x59\x29\xac\xce\xaf\xc5\x27\xbe\x80\x2c\x4d\x49\xd3\xb4\x24\x37\x6f\x1a\xe1\x4a\xa5\x79\x7e\x2a\x86\x93\xb7\xe0\x9d\x10\xd7\x72\x55\xa3\x67\x4b\x64\x31\xda\x78\xbb\x4b\xb3\x4c\x93\x6a\xf8\x12\x52\x3a\xaf\xb7\x60\xe9\x30\xfc\xb0\x49\x32\x96\xed\x0e\x4b\x72\x38\x37\xeb\xb3\x77\xd1\x7f\xdd\x41\x3d\xd8\x3a\xc7\x2c\xe0\xc4\x1e\x5f\x22\xb1\x32\xd4\xa4\x49\x49\x0e\xb7\xce\x9b\x97\xcb\xb1\x8d\xa4\xee\xc4\x0e\x68\xb2\x63\x38\xd8\x3e\x21\xff\x09\x00\x00\xff\xff\xae\x3e\xf2\x90\x62\x0f\x00\x00")
+var _fileMessageJaTxt = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xb5\x58\x4b\x73\xdb\xd6\x15\xde\xdf\x5f\xc1\xb1\x27\x19\x7b\x11\xd9\x75\x93\x4d\xa3\x6a\x91\x36\x93\x69\x3b\x99\x7a\x92\xee\x3a\x1d\x0e\x04\x5e\x51\x88\x48\x80\x01\x40\x3b\xca\x8a\x00\x24\x99\x7a\x99\xb4\xad\x87\x25\x4b\x96\x65\x51\x32\x25\x59\x0f\xc7\xb2\x2c\xeb\xc5\x1f\x73\x09\x80\x5c\xf5\x2f\xf4\x9c\x73\x01\x8a\x94\x58\x7b\xd3\x2e\xf4\x20\xef\xc5\x39\xdf\x79\x7d\xe7\x1c\x5c\x67\xd7\x13\xdf\x73\xcb\x52\xd2\x3c\x31\xa0\x65\xe0\x97\x61\x26\xfe\xaa\xe4\x14\x9d\x5b\x1c\xce\xfe\x64\xe4\x86\x4d\x2d\x3d\x68\x27\x6e\xa8\x37\x13\x77\x6e\xdf\xfe\xea\x8b\x3b\xb7\x7f\xf7\x55\xc2\x1a\xd4\xf4\xef\xbe\xfd\x87\x95\x4f\xdc\x35\x8d\x9f\xb8\x6a\xf7\xb0\xeb\x8c\x65\x14\x3d\xdd\xdb\xf7\x93\xc2\xe0\xc9\x2c\xd7\xf3\x89\x7e\xc5\x64\xb6\x91\xeb\xed\x13\x5e\x51\x78\x9e\xf0\xe6\x99\xce\xef\xf7\xf6\x05\x73\xfb\x8d\x8d\x52\xfd\x6c\x29\x28\x96\x99\xa6\xa7\xf8\x2f\xbd\x7d\xf5\xa3\x42\x63\xe3\x15\x53\x07\x41\x08\xb7\xe0\xce\x52\x21\x7c\xe7\x06\xcf\x0e\xe0\x32\x33\xb9\xca\x75\x9b\x1e\x0c\x97\x0b\x81\x37\xea\xbf\x78\xc3\x2c\xae\x98\xea\x20\x7c\x59\x59\x0a\x0f\x5e\xb2\x2c\xfe\x7f\x47\x1d\x14\xde\x9c\xf0\x36\x85\xbb\x21\xdc\x43\x66\x5a\x20\xea\x87\x1f\x7f\x44\x48\x80\x24\x91\x03\x4b\x59\xc6\x48\x1b\x24\x2b\x58\x2a\xb2\x14\xb7\x54\x53\xcb\xd9\x9a\xa1\xf7\xf6\xdd\xbd\x73\xd7\x9f\xaa\xf9\xe5\xe9\xe0\xe1\x6f\x61\xe5\x38\x58\xae\x31\x4b\xb3\x79\x6f\x9f\x3f\xfa\xda\x3f\x2d\x09\xf7\x9d\x70\x2b\x60\x0c\xb3\x6c\xc5\xce\x83\xe8\x70\xe2\x30\x18\x9d\x64\x4a\xda\xe4\x3c\x4b\x10\x85\x37\x4d\xa6\x82\xc1\x7b\xc2\x3b\x15\xee\x9e\x5f\xdc\x0c\x67\xaa\x60\x70\x78\x30\xc2\x6c\xcd\xce\x80\x3c\xe1\xd6\xa4\x24\xe1\x6d\x47\xd6\x25\xdb\x4d\x6f\xd4\x1e\x09\x67\x37\xb2\x5e\xc9\x64\x10\x41\xb5\xe9\x55\xd1\xca\xa4\xaa\xd8\x3c\x6d\x98\x1a\xde\xf5\xf7\x5d\x69\x30\xa8\x10\xee\xb6\xf0\xc6\x84\x7b\x20\xbc\x2d\x50\x8d\x36\xb7\x59\x47\x96\x26\x23\x6f\x0b\xef\x81\x70\xd7\x84\xfb\x01\xf0\x09\x67\xbb\x5e\x5b\xf6\x77\x9e\x0a\x67\x56\xb8\x53\xa2\xe0\x34\x1e\x6c\xf9\x93\xb3\xe1\xe2\x08\x1c\x49\x0c\xd1\x91\x3b\xd9\x72\x0c\xc0\x93\x21\xbb\xd1\x06\xf7\x48\x38\xd3\x8d\xf3\x53\xe1\xd4\x82\xd9\xfd\xe6\x8b\xb1\x9b\x52\x69\xcb\xb2\xff\xa9\x5a\xba\x11\x2c\xb8\x7e\xf1\xe4\x42\x55\x2b\x53\x08\x54\x3b\x22\x78\x52\x38\xae\x70\xd6\x84\xb3\x72\x55\x9c\x7c\x3a\x4e\xa9\x8f\xe1\x74\x36\x84\x33\xd2\x09\x69\x52\xb8\xe3\x51\x16\x92\x18\x6e\x9a\x86\x09\xb1\x91\xa9\x54\x78\x85\x5a\x6a\xcb\xc1\x94\x43\x18\x56\x84\x8b\xff\x04\x9b\x2b\x0d\xef\x4c\x14\xdc\x66\x61\x2d\x3c\x5c\x0c\x26\x66\xc3\x2a\xc8\x5a\x00\xd1\xc2\xa9\x02\x6c\xe1\xec\x85\x23\xab\xfe\xc4\x07\x40\x20\x9c\x79\x52\x5c\x12\xce\x0b\xc4\xe1\x8c\x44\x9e\x35\xb2\x32\xed\xea\x27\x73\x28\xdc\x7b\x88\x39\xe7\x8d\xe3\x23\x2e\x48\x5e\x0c\x57\xd6\x3b\x65\x82\xa8\x3d\x7f\x7c\xa2\xb9\x50\x81\xfb\x61\x79\x2c\x9c\x79\x23\xdc\xc7\xe4\xa8\x91\xae\x2a\x2c\xae\xa7\xc0\x9f\x6d\x1e\x03\xdf\xfa\xc5\xe5\x4b\x01\x17\xce\x2b\x08\xa1\x70\xb6\x84\x33\x81\x1e\x71\x2a\x17\xe6\xbb\x8f\xc1\x7c\xe1\xac\xa2\xed\xa8\x45\x22\x01\x2d\x8f\x3e\x66\x20\xa4\x2f\x65\x2b\x03\x66\xb2\xb9\x89\x51\x99\xc5\xa8\x78\x60\x5a\x0d\x8a\x26\xcd\x7f\x01\x6a\x09\x76\xd6\xa0\xb4\x1a\xab\xd5\xb0\x74\x1e\x51\x42\xf2\xbe\x61\xa6\x20\xdb\x1a\x5b\xcf\xfd\xd2\x7a\x7b\x64\xe3\x93\x70\xf3\x38\x5c\x38\x03\xc8\xfe\xd4\xb1\x5f\x7c\x20\x93\x42\x5e\x07\xb0\xe8\x30\x07\x40\x6d\xf8\x65\x50\x55\x00\x47\xa2\x6b\xc1\x49\x14\x62\x42\x7a\x8e\x77\x0a\xee\xb5\x9e\x9e\x9e\x6b\x68\x39\x20\xf3\x5e\x53\xb9\x1f\x47\xb7\x0a\xce\xdf\x7f\xc0\x13\xb4\x77\x91\xf2\x06\xb2\xe4\xf1\x27\x25\xc6\x26\x0c\x6a\x36\xe0\xfc\x2c\x55\x3f\x39\x64\xb6\x92\x8e\x18\x63\x1f\x88\xc7\xd4\x90\x65\x83\xb9\x07\xfe\xce\xbc\x5f\x9c\xc7\xd3\x24\x9a\x88\x57\x3e\x08\x6f\x91\x50\x7c\xe8\xb4\x6d\x43\x3e\x8d\x00\x46\xd7\xfd\x89\x67\x57\xbd\x0d\xaa\x3f\xcf\xd8\x5f\x7f\x9e\x86\x1f\x25\x9b\xfb\x1a\xb2\xa4\x7e\x06\x41\x2d\x12\xb2\x67\xc2\x7d\x42\xe0\x06\x0d\xa0\x6f\x74\x76\xe5\x18\xe3\x93\x33\x2c\x9b\xc9\x04\xf9\x64\x02\x32\x5d\xc9\x22\x93\x96\xa7\xfd\xf1\x69\x96\x55\x34\x20\xb5\x6f\xbf\xc0\xbf\xc0\xb1\x69\x1d\xf8\xd4\x84\xe3\xf0\xec\x37\xb8\xc1\xa0\x0f\xa5\x79\x2a\x89\x07\xf1\x77\xc2\x99\x82\x58\x13\xf2\x12\x16\xb2\x3b\xd1\x82\xc6\x14\xdb\x56\xa8\x15\xbc\x3f\xa9\x9f\x3c\xa5\x3c\x59\x25\x7e\xdd\x66\x56\x7e\x60\x40\x03\xc6\x0b\x26\x57\xfd\xd3\x77\xfe\x4e\x99\x45\xd5\xd9\xc1\x56\xc4\x22\x80\xb9\xb1\x55\xf1\xdf\xef\xb2\x56\x59\x09\xf7\xad\xf0\x56\x85\xf7\x16\x49\x1f\xad\x85\xe7\xa8\x50\xe9\x43\xb2\xdf\x48\x0d\x23\xd7\xbc\x86\x70\xa0\x3f\x94\x54\x56\x43\xa2\xcd\x24\xb1\x9b\x76\x56\x8d\x2c\x3a\x3a\x04\x92\x82\x3c\xec\x84\x70\x71\xc3\xe4\x59\xe3\x1e\x7a\x4a\x7e\x54\x15\x5d\xe5\x19\x84\xb2\x23\xbc\x35\x84\xe2\x9e\x90\x61\x32\x51\xa0\xa1\xc6\xca\x90\x2f\xc1\x3b\x23\x17\x5a\xa1\xf0\xce\x96\xda\x8b\x3f\x4e\x38\x0a\x88\x6a\x72\xc5\xe6\x97\xda\x31\x36\xca\x41\x38\x48\x31\x45\x37\xf4\xe1\xac\x81\x6d\x0e\xdc\x0f\x95\x4a\xd2\x67\xd1\xdf\x19\x05\x8c\x57\x4c\x5b\x53\x49\xf1\x52\x81\x74\x77\xf0\x01\x36\xfe\xa4\x31\x90\xc4\x8e\x8b\xa5\x2b\xf3\xf2\x08\xcd\x1c\x2d\x36\x5f\xec\xb0\x7e\xc3\xb6\x8d\x6c\xf7\x2b\xf5\xa3\x49\x70\x03\x96\x70\x6d\xa6\x5e\x5b\x65\x56\x0e\x11\xc9\x20\x56\x5e\xc1\x51\x2a\xaf\x62\xb2\x1c\xed\xfa\xfb\x25\x89\x46\x0a\xa1\x1c\x86\x1f\x09\x09\xa7\x8d\xcb\x07\xf0\xad\x91\x49\x45\xdf\xfa\xa5\x0a\x65\x3c\xfc\x30\x9e\xd2\xec\x64\x5b\xa9\x81\xf3\xc2\xf7\xd5\xe6\xb3\xb1\xc8\x5b\xd6\xb0\xae\x26\x07\x4c\x80\xac\x73\x1b\x78\x64\xa8\x5b\xaf\x97\xbd\x00\xdb\x07\x7e\xac\x51\xc5\x4f\x05\x4b\x2b\x91\x8c\x7b\x5a\x8a\x1b\xd8\x1e\x40\x35\x74\xba\x99\x13\xbc\x30\x36\x1d\xce\xac\xc4\x24\x8d\xf4\x4c\xb7\x5a\x20\x70\xe8\xf0\x96\xa9\xaa\x8a\x14\x81\x95\xf6\x09\x07\xaa\xc2\xaf\x8d\x36\x36\x1c\xe2\x9a\x05\xd9\xf3\x33\xdc\xe6\x6c\x98\x1a\xae\xb3\x27\x89\x3c\x4e\xba\xe4\xcf\x94\xaf\xfe\xd9\x13\xd2\x05\xbf\x77\x6f\xe0\x1f\xec\xb2\x50\xb4\xbb\x37\x3b\x72\x12\xd0\x45\xad\x22\x66\x28\x67\xf2\xdf\xa7\x2b\xad\x0c\xff\xb4\xb4\xb6\x54\xec\x2e\x0a\x00\x53\x41\x32\xdd\x68\xd5\x85\x70\x96\x89\x1f\xc1\x5e\xf0\xfe\x56\x67\x99\xec\x5d\x2a\x7e\xe4\x25\x78\x56\xd6\xc0\xe5\x27\x2f\xea\xaf\xeb\x63\xf9\x4c\xa6\x2d\x8d\x2f\x55\xe3\xd8\xa8\xbf\x0b\x3c\x3a\x05\xcd\x42\x3a\xb7\xf5\x48\x97\x21\xee\xf2\xbd\x7e\x25\xd5\xfd\xda\xb6\x28\x4c\xdd\xfa\xe7\xbf\x62\xb2\x15\x85\x69\x1a\x0a\x36\xa3\x16\x01\xe1\x84\x16\x01\x20\x5b\x93\x46\xdc\x19\xda\xfc\xba\x77\x59\x64\x57\xb2\x96\x50\x87\x73\x58\x28\xd5\xdd\xe6\xea\xf3\x2b\x18\xb5\x74\xec\xb6\x36\xc6\x44\x08\x95\x57\x44\x17\x10\xa3\x87\x17\x9d\xe9\xcb\xdb\xbf\x07\x49\xdb\x93\xd0\x42\xc2\x0d\x27\xd8\x79\x19\x7d\x19\xb1\x60\x87\x0c\xf7\xb1\x6c\x12\x32\xad\x83\xea\x66\x73\xa1\x0c\x82\xaf\xc6\xa0\x57\x49\x00\xdb\x0c\xfc\xf1\xda\xa0\x6d\xe7\xfe\x70\xeb\xd6\xfd\xfb\xf7\x7b\x70\xbb\x48\x73\xdb\xca\xf7\x68\xfa\x80\x71\xeb\x5a\x34\xaa\xf7\xde\x52\xfa\xa8\x1e\x2a\x44\x82\x1f\xc8\xfc\x53\x42\xdc\xa5\x9b\x01\xb2\x2f\xaf\x18\x46\x91\xad\x50\x91\x76\x66\x02\x5c\x8e\xc9\x7c\xc1\x6d\xce\x3d\xa1\x29\x60\x42\x8e\x44\x8d\xcd\x8d\x58\x43\xed\xaa\x1e\x12\x03\xe5\xbb\xf7\xff\xb3\x04\xb2\x3b\xa5\xd8\x0a\x70\xc6\xe9\x2c\xcc\xd5\x9d\x8d\x70\x8b\x40\x4e\xe2\xec\xd5\x62\x9d\x6e\x8e\x06\x0e\x55\xb2\xd1\x8c\xf0\x48\x78\x2f\xa8\x25\xd4\xe8\x79\x39\xd6\x9e\x47\xe4\x02\x57\xe5\x6c\x15\x8f\xb1\xed\x13\x16\x15\x57\x15\xd9\x07\x34\xb5\x12\x89\x67\x73\xf6\x70\x32\xa3\x61\x7b\x24\x41\x2f\xda\x0a\xaf\x0b\x16\xd2\xb4\x4f\xa9\x5c\xf2\xcf\x47\xa3\x11\x85\xa8\xf3\x33\x8b\x1c\xb3\x47\x2b\x8c\x47\x8c\xda\xcd\x25\xc0\x1a\x72\x05\x63\x19\x4d\x1f\x82\x49\x41\x37\x52\xc8\x77\xcd\xc5\xb5\xe0\xe1\x7a\x6b\x0a\x61\x43\xba\x71\x5f\x8f\x0f\x83\x87\x2f\xb1\xf9\xb5\x0e\x31\xf7\xad\x4b\xa3\xed\x2c\x2d\x9b\x72\x4a\xbc\x44\x08\x78\xa6\xc2\x98\xc1\x61\x2c\xf9\x95\x5f\x34\x64\x40\xf9\x5e\x78\xeb\xd1\x72\xe8\x1e\x43\x67\xce\x0c\x90\x4e\xe8\x5f\xb0\xd2\x14\xc7\xe0\x77\xe3\x78\xbb\x7d\x3c\x42\x03\xf2\x39\x88\x2a\x4f\xfc\x9c\xe7\x79\xce\xe4\x87\x24\x7d\x40\xcd\xb8\xdb\x44\xd3\x13\xaa\x59\xc7\x7d\x8e\x06\xd9\xce\x8b\x57\xa6\x2e\xec\x9e\x17\x83\x40\xa7\x01\xf1\x34\x16\x87\x2d\x07\x83\x1b\x0d\x93\xcd\x82\x03\x5d\x96\x22\xb1\xca\x06\x60\x22\xe3\x40\xc2\x7e\xe5\x4d\x30\x3b\x8f\x54\xaf\xdd\xe3\x26\x7e\x23\xaf\x05\x47\x45\x6c\xef\xd2\xbe\x0b\x83\x6c\xb9\x8f\x42\xb1\x34\x56\xa7\xfc\x67\xcf\xd1\x5b\x3a\xff\x05\x5a\xaa\x89\x55\xf5\x7a\x95\x00\xa0\x80\xc8\xd6\x54\x52\xb1\x63\x4b\x83\xf9\x75\xa8\x3b\xd9\xc8\xa3\xac\x6b\xa5\x19\xc4\x03\x44\x24\x3b\x61\xc9\x5c\x8d\x1d\x84\x3d\x14\x64\x13\xa6\xe4\x20\x57\x32\xf6\x60\x1b\x34\xdc\x73\xe4\x9a\x4e\x0e\xec\xb8\x74\xc5\x7f\x55\xe9\x27\x80\x19\xbe\x2b\x07\xcf\x97\x5a\xde\xa2\x65\x10\x8a\xf5\x25\x5e\xd8\x7e\x8a\x79\x78\xf1\xd4\x09\x11\x38\xf8\xa5\xd4\xc4\x08\xec\xc7\x05\x25\x27\x7a\x48\x27\x2e\x2b\x8f\x04\x80\x99\x36\xd7\x55\xf0\x8a\x5f\x5b\x0a\x77\x66\x24\xe3\xc0\xa0\xaa\xaa\xdc\xb2\x28\x23\x8b\x65\x7f\x62\x85\x22\x91\xa7\x31\x28\x8a\x85\xa6\x27\x95\xa4\x89\x23\xb8\xdc\x19\x59\xce\x34\x6c\x43\x35\x32\xd2\x69\x16\xbe\x45\x99\x26\x6e\xd8\x92\xa2\x21\x2a\x8a\x6e\x0d\xc0\x29\xba\x4e\x78\x65\xd9\x35\x30\x36\xe4\x6b\x8b\x73\x3d\x7e\xd5\x12\xbe\x3c\x6e\x6c\x4d\x33\x74\x37\x8c\xbe\xf4\x3a\x44\xee\xbd\x30\xbe\x20\xbe\x68\xbd\x6e\x1d\xca\x90\x06\xb3\x07\xb0\x44\xe2\xb6\x11\x75\xf7\x78\x61\xbf\xb4\x1a\x92\x2b\xfc\xf2\x08\xf1\xcf\x6b\x9a\x9a\xc6\xe5\x2a\x09\xe1\xb7\x94\x7b\xb2\xa8\x60\xdb\x01\x26\x95\x0a\xa1\xaf\x35\xe7\x5e\xd6\x6b\x2e\x71\x54\xa9\x73\x07\x8f\x7d\x6b\xe6\xf5\x76\x4c\xf5\x93\x09\xea\x5a\xe5\x68\x76\xc6\x49\xaf\x55\xce\x30\xec\x41\xd4\x1a\xd5\xa7\xf5\xe3\xc9\x68\xc8\x6e\x3f\xa5\x27\x64\x72\x47\x77\x68\x22\x69\xc9\xfe\xa8\x81\xdd\xa6\x8b\x94\x32\x8c\xb1\x9c\x5f\x67\x79\x3d\xa3\x65\x35\x1b\x63\x80\x0b\x6f\xf1\x10\xba\x21\x63\x03\x96\x3a\x24\x5f\x35\x48\xd6\xf6\x16\xe4\xea\x06\x33\x7a\xb0\x72\x22\xfd\x2d\xef\x5c\xaa\xe3\xbf\xfc\x19\xd8\xfe\xea\xaa\x83\x33\x4b\xc9\x0d\x47\xe5\x1e\x3e\x41\xf9\xf8\xb6\x95\xa4\xc0\xdf\xc2\x83\xc5\x61\x83\x94\x1c\x46\xe3\xa5\x77\x02\xa5\x48\x0c\x73\x2a\x83\xe9\x97\x8b\x72\x63\x97\x28\x3a\x56\x53\x93\xe7\x14\xcd\x4c\x42\xd2\xf5\x67\x78\x16\xb3\xad\xb6\xeb\x9f\x6f\x92\x21\x5d\xeb\x53\xbe\xeb\xa8\x9f\x01\x46\xd7\xaf\x8c\x43\xad\x77\xbc\x43\x69\xad\x6f\xfd\x79\x75\x88\xdb\x51\x82\xbe\x91\x23\x35\x1b\xe2\xc3\x11\xc7\x9e\xb2\x48\x25\x48\x9f\x2d\x35\x57\xa7\x22\x24\x4a\x3f\xce\x2e\x12\x84\x5f\xda\x6b\x78\x67\xd1\x01\xe2\x90\x5f\xb7\xc8\xaa\x0d\xb5\x94\x01\x31\x6b\x6c\x4c\x76\x99\x06\x30\x59\xe3\x46\x88\x2f\x32\x35\x4b\x65\x14\x3e\x2a\x14\x5c\x3f\xb2\xfd\xbd\x7d\xdf\x7f\xc3\x86\xe0\xcf\xdf\xbe\x61\x69\xc3\x48\x23\x8e\xef\xe8\x2f\xbe\xac\x33\xd4\x64\x16\xc1\xc2\x4c\x16\xce\x54\xeb\x47\x3b\xb4\x42\x42\xe3\xd9\x82\xa5\xc8\x56\x32\x6d\x57\xa4\x44\x79\xf1\xe2\x56\x3e\x97\x31\x94\x14\x95\x2c\xf0\x0d\xbd\x38\x15\xde\x8e\x8c\x63\xe2\x06\x24\x39\x24\x55\xe2\x56\x02\xff\x59\x2a\xde\x64\x29\xe8\x71\xad\x07\xbc\x02\x2e\x06\xb8\xb0\xfe\xf7\x07\x54\x43\xd7\xb9\x8a\x69\x9d\x8c\x5f\x64\x42\xd7\x24\x42\x31\x4c\xfb\x36\x64\xe9\xf8\x03\xdf\x39\x90\xdf\xb5\xde\x59\x41\x16\x62\x0a\xe2\xcb\xab\x38\x70\x26\xcf\x28\xc3\xe4\xed\xa3\x9d\xf0\x70\x81\x56\x15\xd0\x59\x0e\xdf\x4d\x85\x33\x6f\x98\x01\x1d\x86\x92\xfe\xd9\x51\xfd\xf8\x71\xa4\x22\x05\x0e\x95\xfa\x79\x2a\xd6\x1b\x2c\x6d\xb1\xff\x00\x64\x52\x41\x89\x8c\x16\x00\x00")
 
 func fileMessageJaTxtBytes() ([]byte, error) {
 	return bindataRead(
@@ -1013,7 +1060,27 @@ func fileMessageJaTxt() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "file/message-ja.txt", size: 3938, mode: os.FileMode(420), modTime: time.Unix(1502257508, 0)}
+	info := bindataFileInfo{name: "file/message-ja.txt", size: 5772, mode: os.FileMode(420), modTime: time.Unix(1792198231, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _fileModeratorTxt = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4d\x8e\xb1\x0e\x82\x40\x10\x44\x7b\xbe\x62\x12\x1a\x6d\xfc\x0a\x4b\x7b\x4b\x73\x70\x03\x6c\x84\x5b\x72\xbb\x62\xf8\x7b\x0f\x2f\x26\x96\xfb\xe6\xed\x64\xda\xa6\xc5\x4d\xcc\xa1\x03\xd6\x57\x37\x4b\x8f\x27\x77\x3b\xce\x45\x23\x73\x70\xcd\x76\x69\xda\xa2\xdd\xb3\x38\xa1\x89\x7f\x22\x56\xe6\x2f\x9a\x25\xb1\x68\xb8\x72\xa6\x8b\x26\x24\x75\xe9\x69\x38\x65\xf6\x9a\xa3\xe1\x2d\x3e\x21\x73\xd1\x8d\x0f\xf3\xb0\xac\x08\x29\xfe\x80\xc4\x73\x79\x36\x19\x13\x23\xba\x1d\x3e\xd1\x58\x97\x54\xe3\x20\xf0\x90\x47\x3a\x6a\x63\xf1\xb9\x31\x41\x86\xff\x4c\x0c\xab\x9a\xd7\x16\x2d\x41\x5d\xff\x01\x88\x0b\xa0\x20\xe7\x00\x00\x00")
+
+func fileModeratorTxtBytes() ([]byte, error) {
+	return bindataRead(
+		_fileModeratorTxt,
+		"file/moderator.txt",
+	)
+}
+
+func fileModeratorTxt() (*asset, error) {
+	bytes, err := fileModeratorTxtBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "file/moderator.txt", size: 231, mode: os.FileMode(420), modTime: time.Unix(1792194827, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -1218,6 +1285,26 @@ func gou_templateFooterTxt() (*asset, error) {
 	return a, nil
 }
 
+var _gou_templateFsckTxt = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x85\x53\x4d\x6b\xdc\x30\x10\xbd\xef\xaf\x18\xc4\x1e\x92\x40\xec\xb6\x87\x1e\x82\xbd\x90\xa4\xa5\x94\x52\x28\xcd\xa9\xa7\x45\x6b\xc9\x6b\xb1\xb6\x24\x24\xb9\xd4\x18\xfd\xf7\x8e\x3e\x9c\x75\x36\x29\xbd\xd8\xd2\x9b\xaf\x37\x33\x4f\xf3\x5c\xde\x6c\xe0\x51\xe9\xc9\x88\x63\xe7\xe0\xaa\xb9\x86\x0f\xef\xde\x7f\x84\xa7\x4e\xc8\x89\xc2\x2f\x7a\x9c\xc6\x62\x03\x37\xa5\xf7\x9b\x79\x66\xbc\x15\x92\x03\x69\x6d\x73\x22\x11\xd9\x1a\xa5\xdc\x5d\x5d\xe0\xa5\xd2\xbb\x79\x2e\xbe\x73\x6b\xe9\x91\x17\x8c\xdb\x66\x1f\xfc\xbc\xaf\x4a\xbd\x43\x57\x10\x2d\x14\x9f\x8d\x81\xe8\x0b\x4d\x4f\xad\xad\x89\xe3\x7f\xdc\x2d\xa3\xf2\xc8\x0d\x59\xc7\x87\xd0\x7d\x4b\x45\xcf\x99\xf7\x77\x80\x16\x0c\x3d\xe7\xe2\x92\x41\x24\x10\xb3\xfe\x30\xea\xd0\xf3\xc1\x06\x08\x20\x81\x14\x3d\x8a\x9f\x5c\x53\x61\x28\x1a\xe1\x4a\x2a\xb7\x00\x9c\x5d\x27\xd7\xaa\x55\x66\x80\x81\xbb\x4e\xb1\x9a\x68\x65\x1d\x01\xda\x38\xa1\x64\x4d\xb0\xe4\x3d\x1b\x84\x7c\xfc\xf2\xd5\xfb\x32\xb6\xbc\xc3\x10\x0c\x12\x52\x8f\x0e\xdc\xa4\x79\x4d\x3a\xc1\x18\x97\x04\x24\x1d\xf0\xd6\x0c\x8c\xc0\x6f\xda\x8f\x78\x36\xb1\x14\x81\xf2\xbf\x51\x56\x9c\xa3\xb0\xea\x93\xc0\x96\xdf\x8e\xb3\xe3\x61\x10\x6e\xed\xbc\xcc\x2b\x55\xdb\xeb\x3c\x89\x90\x20\x4f\xf8\xe0\x64\x4e\x56\x95\xa1\xdd\x5d\x1a\x51\x9e\x60\xe5\xe2\x74\x96\x6d\xc4\x4b\xfc\xde\x36\x4a\x22\x47\xcb\x59\x6c\xbb\x72\x26\xf3\x71\xdd\x7a\x4f\x87\xb1\x39\x71\x17\x16\x83\xf8\x85\xed\xc4\xa7\xb7\x0d\x99\xe5\xca\x18\x0f\x91\x62\xaa\x83\x04\x4d\x10\x05\x6c\x35\xaa\xeb\xe5\x7e\x57\x54\x18\x66\xdd\xea\xe2\xe1\x4c\x82\x5d\x98\xbe\x65\x0e\x97\xf8\x27\xd4\xe7\x6b\x43\x10\x0e\x1a\x57\xb2\xf1\x3e\xa3\x41\xe8\xcf\xf2\x89\x70\x82\x5e\x2e\x20\xa8\x35\x0c\xb7\xb7\xfc\x9f\x3e\x21\x6f\xf2\x92\x29\x51\x3e\x2c\x64\x56\x33\x58\x96\x54\xc6\x95\x24\xe5\xa7\xd4\x17\xcf\x4d\xaa\xd5\xea\x5f\x3d\x12\x3c\xe0\xff\x2f\xad\x99\x91\x9e\xed\x03\x00\x00")
+
+func gou_templateFsckTxtBytes() ([]byte, error) {
+	return bindataRead(
+		_gou_templateFsckTxt,
+		"gou_template/fsck.txt",
+	)
+}
+
+func gou_templateFsckTxt() (*asset, error) {
+	bytes, err := gou_templateFsckTxtBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "gou_template/fsck.txt", size: 1005, mode: os.FileMode(420), modTime: time.Unix(1792198231, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _gou_templateHeaderTxt = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xb4\x55\xdf\x6f\xdb\x36\x10\x7e\xd7\x5f\x71\x25\xf2\xb0\x75\xb3\x18\x77\x1b\xb0\xa4\x92\x8a\xc2\xce\xb2\x0c\x49\x9b\xc5\x1e\xb0\xa1\xe8\x03\x23\x5d\x24\x7a\x12\xa9\x92\xe7\x5f\x23\xf4\xbf\x0f\xb4\xa4\x5a\x76\xda\x21\xc5\xb6\x27\x9b\xf7\xeb\xe3\x7d\x77\xfa\xe8\x1c\x7f\x1e\xc0\x44\xd7\x5b\x23\xf3\x82\xe0\xab\xf4\x6b\x78\x71\x7a\xfa\xc3\xe8\xc5\xe9\xf8\x7b\xb0\x85\x54\x97\x17\x73\xbb\x84\x5b\xa3\x17\x98\x52\x18\xc0\x73\xde\x34\x81\x73\x19\x3e\x48\x85\xc0\x0a\x14\x19\x1a\xd6\x34\x41\xf4\x6c\xfa\x76\x32\xff\xe3\xf6\x02\x7e\x9e\xdf\x5c\x27\x81\x73\x27\x46\x6b\x3a\x8f\x43\xef\x2c\xa8\x2a\x61\x53\x95\xca\xc6\xac\x20\xaa\xcf\x39\x5f\xaf\xd7\xe1\xfa\xbb\x50\x9b\x9c\x8f\xcf\xce\xce\xf8\xc6\xc7\xb0\x00\xa0\x14\x2a\x8f\x99\x73\xe1\x0d\x5a\x2b\x72\x0c\xbd\xa1\x69\x98\xcf\x3f\xff\x9c\x33\x09\x22\x7f\x97\x24\x00\x88\x2a\x24\x01\x1e\x65\x84\x1f\x96\x72\x15\xb3\x54\x2b\x42\x45\x23\xda\xd6\xc8\xa0\x3b\xc5\x8c\x70\x43\xdc\xa3\xbe\x84\xb4\x10\xc6\x22\xc5\xbf\xcd\x7f\x1a\xfd\xc8\x80\xef\xea\x90\xa4\x12\x13\xe7\xc2\xb9\xff\xd3\x34\x11\x6f\x2d\x81\x73\x20\x1f\x20\x9c\xa2\xda\xde\xe9\x7b\x4d\xd0\x34\x1f\x71\x95\xa8\x30\x66\xc6\x9b\xed\x00\xeb\xcd\xdb\xab\x37\xd3\x8b\xdf\x77\xa5\x9d\x03\x54\xd9\xe3\xa4\x95\xc4\x75\xad\x0d\x0d\xd2\xd6\x32\xa3\x22\xce\x70\x25\x53\x1c\xed\x0e\xdf\x82\x54\x92\xa4\x28\x47\x36\x15\x25\xc6\xe3\xf0\xb4\xbf\x6f\x29\xd5\x9f\x60\xb0\x8c\x99\x58\x52\xa1\x0d\x83\xc2\xe0\xc3\x01\xe1\x7e\xa4\x39\x92\x5d\x86\x52\x3d\x68\xfe\x38\xb3\x43\xb6\x7d\xee\x27\x42\xa4\xca\x70\xd3\xfb\x9d\x0b\xef\xb4\xa6\x5b\x41\x45\xd3\xe4\x82\x70\x2d\xb6\x61\x9a\x4b\xde\x45\xb5\xc9\x36\x35\xb2\x26\xf0\xfc\x77\xb4\x2f\xc4\x4a\xb4\x56\x06\xd6\xa4\x47\x85\x16\x1f\x96\x68\xb6\xbc\xfd\x09\x2b\xa9\xc2\x85\x7d\xe5\x5c\x38\x5d\x56\xd5\x76\x67\xf4\x23\x8f\x78\x5b\xe1\x3f\x80\x28\xc5\x5f\xff\x13\xce\xbd\xd6\x64\xc9\x88\x9a\x2f\x2c\xff\x78\x78\x1a\xd6\x60\xa0\x25\xa1\x51\x82\x90\x75\xc8\xa2\xae\x4b\x99\x0a\x92\x5a\x71\x63\xed\x37\x9b\xaa\x64\xb0\x5b\xcf\x98\xdd\xcd\x66\xc3\xf1\xcc\x66\xfe\xe3\x39\x9e\xa2\xa5\x6d\x89\xb6\x40\x24\x36\x6c\x26\xb5\xf6\x93\xa3\xdd\xb7\x91\xda\xe3\x3e\x52\xfb\xb8\x91\x76\xcf\x8d\x50\x39\xc2\x09\x9e\xc7\xe1\x2f\xb3\x6e\xe1\x9f\x44\xe0\x4e\x3b\x06\xf0\xce\x9d\x60\xd3\xbc\xea\x1d\x9f\xe3\xcc\x39\x2c\x2d\xfe\x1b\xa0\xe1\x0a\x57\x68\x72\xcc\x76\x53\x6a\xc3\x6e\x3a\x43\x78\x89\x74\x2d\x08\x2d\x3d\x82\x6f\xbf\xeb\x83\xce\x27\xb3\xbe\xf5\x2f\x63\xff\x4b\x38\x68\xe9\xde\xab\xca\x3f\xf6\x9e\x70\x1e\x3d\x7b\x37\x99\xbe\x9e\xbf\x7e\x17\x00\x00\xec\x55\xc1\x74\x68\x10\xc3\xe1\xfc\xd9\xcb\xa3\xc8\xcc\xc3\xff\xea\xe1\xbb\xd8\x83\xfb\x1c\x47\x2f\xe5\xb5\x50\x79\x17\x79\x24\xdd\x3e\x96\xf3\xf7\xef\x07\x44\x46\xbc\x15\xf3\xe8\x5e\x67\x5b\xdf\x99\x97\xdb\x1b\x54\xcb\x7b\x61\x76\xf4\x12\x56\x75\x29\x08\x81\x55\xad\x95\x1d\xfa\x51\x65\xfe\xd9\xc9\xe4\x0a\xd2\x52\x58\xdb\x0a\x9b\x90\x0a\x0d\x4b\x82\x5e\xc0\xaf\xec\xbc\x30\x28\x7a\xd2\x8a\x71\x12\x89\xfd\xf2\xb7\xbe\xc9\xe5\x55\xd3\x70\xe7\x2c\x99\x0b\x95\xea\x0c\xa1\x7f\x0e\x58\xe2\x1c\xda\x54\xd4\x38\xab\x45\xba\xb7\x47\x5c\x24\x11\x2f\xc6\xad\xd0\xef\xf7\x71\x58\xfe\x69\xb9\xfd\x32\xb5\xdd\xfc\x1d\x00\x00\xff\xff\xbd\x5c\x32\x67\xad\x07\x00\x00")
 
 func gou_templateHeaderTxtBytes() ([]byte, error) {
@@ -1298,7 +1385,7 @@ func gou_templateList_itemTxt() (*asset, error) {
 	return a, nil
 }
 
-var _gou_templateMenubarTxt = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x8c\x92\xc1\x6e\xe2\x30\x10\x86\xef\x79\x8a\x91\x4f\xbb\x48\x24\x2c\xda\xbd\x85\x48\x2b\x68\x51\x0e\xad\x2a\xe8\x0b\x18\x67\x20\xae\x8c\x13\x79\x1c\x28\xb2\xfc\xee\x95\x93\x54\x45\xae\x54\xb8\x7e\x99\xff\xfb\x63\x8f\x9d\xcb\x26\x09\x2c\x9b\xf6\x62\xe4\xa1\xb6\xf0\x4b\xfc\x86\xf9\x6c\xf6\x6f\x3a\x9f\xfd\xf9\x0b\x54\x4b\xbd\x7e\x78\xa5\x0e\x5e\x4c\xf3\x86\xc2\xa6\x09\x4c\x32\xef\x13\xe7\x2a\xdc\x4b\x8d\xc0\x8e\xa8\xbb\x1d\x37\xac\x87\x20\xf7\x90\x96\x2b\xef\x13\x80\x9c\x93\xac\x10\x84\xe2\x44\x0b\xa6\xf9\x69\xc7\xcd\x54\x34\x4a\xf1\x96\x90\x81\xac\x16\xcc\xb9\x7e\x98\x15\x21\x8a\x8a\x10\xee\x48\x0e\xc3\xba\x0a\xb3\x79\xa7\xae\xc6\x40\xf3\xd3\xb4\x95\x4a\x11\x8c\x21\xcd\x4f\xac\x08\x46\x25\x8b\x9c\x43\x6d\x70\xdf\xb7\xae\xb9\xc5\x33\xbf\x2c\xd7\x65\x68\x77\x2e\x7d\x42\x22\x7e\xc0\xd4\x36\xad\xf7\x79\xc6\x8b\x3c\x53\x32\x24\x7f\xce\x66\xa2\xe6\xfa\x80\xc4\xc0\x4a\xab\xb0\xff\xbe\x42\x12\xcb\x01\x47\x72\xf1\x49\x43\xc1\x6d\xb7\xd4\x15\xbe\xc7\xe6\x32\xc0\xc8\x2b\x07\x36\x5a\x87\x2d\x34\x06\xd2\x92\x1e\x8d\x0c\x37\x95\x96\xf4\xbf\x3a\x4a\x3d\x5c\xef\x8d\x5a\x83\x02\xb5\x8d\x7b\x37\x3d\x8d\x8a\xcd\x08\xef\x3b\x8f\xc6\x73\x6c\x7d\xc6\x73\xa4\xd4\x81\x7c\x9d\x64\xdc\xf3\x77\xf3\x66\xbb\x8d\x7f\x86\xe8\x7a\x75\x79\xd6\xa9\x22\x49\xf2\xac\x7f\x4c\xe1\xd1\xa0\xae\xbc\x4f\x3e\x02\x00\x00\xff\xff\xbf\xd0\xc3\x24\xf1\x02\x00\x00")
+var _gou_templateMenubarTxt = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x8d\x92\x51\x4b\xc3\x30\x14\x85\xdf\xfb\x2b\x2e\x79\xd2\xc1\xd6\x39\xf4\xad\x2b\xc8\xa6\x63\x0f\x8a\x38\xff\x40\x4c\xef\xd6\x48\x96\x96\xdc\xd4\x39\x4a\xff\xbb\x49\x5a\x71\x44\x70\x7b\x28\x29\xa7\xe7\x7c\xa7\xe1\xde\xb6\x4d\x47\x09\x2c\xaa\xfa\x68\xe4\xae\xb4\x70\x25\xae\x61\x36\x9d\xde\x8d\x67\xd3\x9b\x5b\xa0\x52\xea\xd5\xc3\x1b\x35\xf0\x62\xaa\x0f\x14\x76\x92\xc0\x28\xed\xba\xa4\x6d\x0b\xdc\x4a\x8d\xc0\xf6\xa8\x9b\x77\x6e\x58\x10\x41\x6e\x61\xb2\x5e\xba\x77\x80\x8c\x93\x2c\x10\x84\xe2\x44\x73\xa6\xf9\xa7\x73\x8d\x45\xa5\x14\xaf\x09\x19\xc8\x62\xce\xda\x36\x98\x59\xee\xa3\xa8\x08\xe1\x82\x64\x6f\xd6\x85\xf7\x66\x8d\x3a\xb1\x81\x7b\xc6\xb5\x54\x8a\x60\x08\xb9\xc3\xf9\x1d\x51\xc9\x3c\xe3\x50\x1a\xdc\x86\xd6\x15\xb7\x78\xe0\xc7\xc5\x6a\xed\xdb\x9d\xf0\x84\x44\x7c\x87\x13\x5b\xd5\x5d\x97\xa5\x3c\xcf\x52\x17\x71\xc9\xff\xb3\xa9\x28\xb9\xde\x21\x31\xb0\xd2\x2a\x0c\xdf\x97\x48\x62\xd1\xcb\x11\x5c\xfc\xa8\xbe\xe0\x3c\x5b\xea\x02\xbf\x62\xf2\xda\x8b\x11\x57\xf6\xda\x65\x54\x42\x6e\x44\x19\x63\x37\x41\x8d\xb8\x34\x88\x03\xb8\x1f\x6f\x65\xdc\x84\xe9\xd1\x48\x3f\x02\xf7\x76\x5f\xec\xa5\xee\xe7\x76\xa6\xd9\xa0\x40\x6d\xe3\xe6\xd7\xa0\x46\xcd\x66\x10\x2f\xbb\x92\xc6\x43\x4c\x7d\xc6\x43\x84\xd4\x5e\xf9\xbd\xc9\xb0\x40\x7f\xc9\xaf\x9b\x4d\xfc\x33\x44\xa7\x3b\x91\xa5\x8d\xca\x13\x77\x84\x2d\xf5\xdb\xe8\x58\x0e\xf5\x0d\x2b\xac\xfd\xe4\x4a\x03\x00\x00")
 
 func gou_templateMenubarTxtBytes() ([]byte, error) {
 	return bindataRead(
@@ -1313,7 +1400,7 @@ func gou_templateMenubarTxt() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "gou_template/menubar.txt", size: 753, mode: os.FileMode(420), modTime: time.Unix(1491401929, 0)}
+	info := bindataFileInfo{name: "gou_template/menubar.txt", size: 842, mode: os.FileMode(420), modTime: time.Unix(1792195046, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -1338,6 +1425,26 @@ func gou_templateNew_element_formTxt() (*asset, error) {
 	return a, nil
 }
 
+var _gou_templateNode_healthTxt = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x7d\x53\x4d\x6b\xc3\x30\x0c\xbd\xe7\x57\x88\xd0\x41\x5b\x68\xba\xf5\xb0\xc3\x68\x7b\xd8\x17\x1b\x74\x63\xd0\xd3\x4e\xc1\xb3\x95\x26\x90\xda\xc1\x72\xd9\x42\xc8\x7f\x9f\x9c\x76\x69\x5a\xd2\x5d\x62\x5b\x4f\x7e\x7a\x7a\x72\xaa\x6a\x3a\x0e\xe0\xc1\x14\xa5\xcd\x36\xa9\x83\xa1\x1c\xc1\xec\xfa\xe6\x16\xd6\x69\xa6\x4b\x01\x9f\x62\x53\xee\xa2\x00\xc6\xd3\xba\x0e\xaa\x4a\x61\x92\x69\x84\x50\x1b\x85\x71\x8a\x22\x77\x69\xd8\x00\x03\x6b\x8c\xbb\x5b\x44\x7c\x98\x17\xcb\xaa\x8a\xde\x90\x48\x6c\x30\x52\x48\x32\xee\xa4\xd7\xf5\x7c\x5a\x2c\x83\xb9\x13\x5f\x39\x82\xcc\x05\xd1\x22\xdc\x1f\x9a\xef\x44\x1a\xad\x50\x13\xaa\x70\x19\x00\xcc\x9d\xf5\x8b\xdf\xa4\x5d\x5a\xcf\xe8\xa9\x38\x7a\x86\x90\x34\xf6\x02\x94\x0b\x87\x5a\x96\x07\xb0\x8f\x96\x76\x52\xf2\x16\xa9\x9f\x20\x11\x59\xbe\xb3\x97\xd0\xc2\x1a\x67\xa4\xc9\x63\xb4\xd6\x58\xfa\xa7\x8c\xb3\x42\x53\xc2\x69\xa8\x2e\x29\x25\x17\x13\xa2\x3e\x92\xf0\xda\x58\x51\x55\xc0\xb7\x37\x08\x83\x94\xfd\x7e\x69\x4c\x25\x60\xdf\x1b\xb3\x18\xcd\x12\x86\xa2\x57\x7a\x44\xa1\x38\xde\x7a\x8c\x3f\x6e\xb2\xdd\x39\x36\x96\x93\x50\x7b\xec\x4f\x9c\xe2\xda\x7c\xe7\x9d\x4d\x25\x67\x9b\x9a\xaa\x8b\x15\x36\xd3\x2e\x81\xf0\x2a\x9a\x25\xa1\x67\x5f\xb7\x26\xab\x33\x8e\xd5\x9f\xc7\x5b\xea\x41\xd7\x27\xfe\x9e\xa3\xcf\xad\xbd\x6d\x1b\x3e\xe4\xbb\x83\x61\x9b\xc1\x30\x1c\x1e\x5c\xeb\x57\xa6\x63\x11\x5b\xf3\x5d\xd7\xa3\xb6\xb9\x9e\x02\x1f\x87\x09\x3d\x1d\x07\x74\x9e\x72\x5f\xba\x3e\x75\x07\x39\x2b\x9e\xcb\x9a\xc7\x02\x5e\x62\x6e\x24\x9b\x9f\x6d\xb1\x8b\x34\xda\x4f\x05\x74\x26\xb7\x07\x02\x8e\xf8\xb7\xbe\xe4\x1f\x87\x23\x1c\xf8\x05\xd5\xc4\x46\x2d\x87\x03\x00\x00")
+
+func gou_templateNode_healthTxtBytes() ([]byte, error) {
+	return bindataRead(
+		_gou_templateNode_healthTxt,
+		"gou_template/node_health.txt",
+	)
+}
+
+func gou_templateNode_healthTxt() (*asset, error) {
+	bytes, err := gou_templateNode_healthTxtBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "gou_template/node_health.txt", size: 903, mode: os.FileMode(420), modTime: time.Unix(1792196388, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _gou_templatePage_naviTxt = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x9c\x92\xcf\x8e\xd3\x30\x10\xc6\xef\x79\x8a\x51\x94\x43\xb2\x08\xa7\x5d\xc1\xa5\xda\xec\xa5\x2c\xab\x95\x40\x5a\x89\xbd\x57\x26\x9e\x24\x46\xc1\x36\xb6\x0b\x82\xc1\xef\x8e\x9c\xa6\xee\x1f\xa9\x48\xdd\x5b\x66\x26\xf3\x7d\xe3\xdf\x0c\x51\x7d\x93\xc1\x5a\x9b\xdf\x56\xf6\x83\x87\xb2\xad\xe0\x76\xb1\x78\xff\xf6\x76\xb1\x7c\x07\x6e\x90\xea\xf1\xe1\xc5\x6d\xe1\xd9\xea\x6f\xd8\x7a\x96\xc1\x4d\x1d\x42\x46\x24\xb0\x93\x0a\x21\x37\xbc\xc7\x8d\xe2\x3f\x65\x3e\xa5\x0b\xab\xb5\x5f\x35\x6c\x0a\x40\x76\xc0\xd6\xbc\x1d\xf0\x13\xaa\x10\xfe\x12\x01\x2a\x01\xa9\xa6\x2d\xb0\x67\xde\x23\xb0\xa7\x0f\x31\x0b\x70\xc7\x61\xb0\xd8\x35\x39\x11\x7b\x19\x2c\x72\xb1\x7e\x7c\x0a\xa1\x26\x72\xde\x3e\xa8\x56\x0b\x8c\x2d\x7e\x08\x21\xbf\x27\x62\x9f\xd1\x39\xde\x23\x1b\xb9\xf3\x9b\x38\x4a\x08\x77\x35\xbf\xcf\xce\x9d\x7a\x3f\x3b\x2d\xaf\xb5\xa9\x0d\x91\xdb\x7e\x3d\xb4\x9f\xf8\x2a\xfc\xf5\x5f\x5b\x04\xf6\x51\x5a\xe7\x67\x5f\x22\xb0\x5c\xf5\x08\x85\x59\x35\x93\xa2\xdb\xbd\x7b\x2a\xc9\x0e\xb8\x12\x50\x02\xfe\x80\x89\x63\xc4\x92\xe7\x15\x94\x31\x61\xe6\x5c\xec\xaa\xf6\x5d\xb1\xaf\x30\x49\x02\x47\x87\x47\x95\xa4\xa8\xb4\x4f\x8a\x15\xcc\xb1\xa9\xd2\xaf\x27\x44\x76\x3f\x5e\xc2\xb2\x1f\x62\xbf\x82\xe8\x3e\xbd\x3d\x99\xc6\x21\xe0\x48\xfa\x75\xda\x91\x7b\xd4\xbe\xe0\xb1\xa3\x7c\x1e\x9d\xf0\x4f\x5f\x85\x31\x66\xd5\x7c\xdf\x8e\x50\x72\x21\xe6\x4d\xbe\x59\x56\x30\x0f\x12\xe3\x2f\xf2\x0f\xa6\xb5\x25\x66\x13\xae\x72\x8c\xb0\x8c\x39\x9c\x32\x24\x72\x57\x1e\xd2\xc1\xfe\xfc\x90\xf4\x28\x2e\x1d\x12\x2a\x11\x42\xf6\x2f\x00\x00\xff\xff\x2f\x7d\x18\xbb\xa9\x03\x00\x00")
 
 func gou_templatePage_naviTxtBytes() ([]byte, error) {
@@ -1378,7 +1485,7 @@ func gou_templatePost_formTxt() (*asset, error) {
 	return a, nil
 }
 
-var _gou_templateRecordTxt = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xb4\x55\xc1\x6e\xe3\x36\x10\xbd\xeb\x2b\x06\xc4\x16\xb0\x17\x88\xe4\x75\xb7\x97\xc0\x32\x90\xdd\x04\x59\xa3\x28\x10\xd4\x41\x2f\xc1\x22\xa0\x45\x4a\x62\x22\x91\x2a\x49\xb9\x71\x58\xfe\x7b\x31\x94\xec\x48\x8e\x9b\xa2\x0b\xe4\x26\xcf\x0c\xe7\xbd\x37\xf3\x48\x3b\x97\x7c\x8c\xe0\xab\x6a\x76\x5a\x14\xa5\x85\x49\x36\x85\xf9\x6c\xf6\xcb\xd9\x7c\xf6\xe9\x33\x98\x52\xc8\xeb\xab\x5b\xd3\xc2\x8d\x56\x0f\x3c\xb3\x71\x04\x1f\x13\xef\x23\xe7\x18\xcf\x85\xe4\x40\x34\xcf\x94\x66\xc4\xfb\x68\xc1\x2c\x08\x96\x12\xed\x5c\xbc\x16\xcc\x7b\x02\x8c\x5a\x7a\xd6\x55\x9c\x61\xea\x90\x59\x46\xce\x81\xc8\x21\x5e\x99\x0b\x56\x0b\x09\xde\x47\x00\x0b\x21\x9b\xd6\x82\xdd\x35\x3c\x25\x59\xc9\xb3\xc7\x8d\x7a\x22\x20\x69\xcd\xd3\x3d\x10\x6c\x69\xd5\xf2\xd0\xea\x77\x9e\x7d\xe3\x94\xc5\x6b\x4b\xeb\xc6\xfb\xfb\x41\x68\x75\x89\xf0\x49\x80\xe1\x92\x61\xfb\x05\x85\x52\xf3\x3c\x9c\xbc\x2d\x35\xa7\xec\xeb\xf5\xca\xfb\xc4\x39\x63\xf5\x95\xcc\x14\xe3\x10\xdf\x50\x5b\x86\xd8\x5e\x41\x56\x51\x63\x52\x22\x18\x09\xda\xc4\x4b\xa6\x63\xf5\x12\x58\xee\xbf\x16\x09\x45\xdc\x0f\x58\x70\x9e\x22\xa5\xf8\x9a\xdb\x2f\x8a\xed\xfe\x40\xea\x40\x30\x41\x80\x10\x08\x83\xc4\x31\x84\xda\x7e\x06\xa6\xa1\x72\x0f\x1b\x2a\x97\x7d\x2f\xec\x8c\xc9\x4e\x54\x65\xde\x3a\x10\xff\xc6\x8d\xa1\x05\x8f\xa9\x54\x72\x57\xab\xd6\x8c\x4f\x77\x23\x71\xee\x43\x4d\x45\x75\x92\x24\x26\x8e\x48\x62\x28\x40\xde\xf5\x07\xbd\xff\x3e\xee\xd6\xb4\x9b\x47\xbe\xeb\xfb\xad\x4b\xa5\xed\x4d\x88\x0c\x9a\x74\x25\xaf\x99\x1b\x51\x48\x02\x56\xd8\xaa\x5b\xee\x5e\x00\xc6\xa9\x6d\x35\xf7\xfe\xbc\x5b\xf0\x11\x51\x4b\x75\xc1\x2d\x52\xed\x96\x70\x40\x78\xad\x77\x0c\x88\xae\xe9\x2d\x1a\xbe\x4f\x79\x0a\x1b\x56\x2a\xa3\x95\x15\x35\x87\xe3\xec\x00\x01\xbd\x8c\xdc\xbe\x51\x33\xe0\x46\xad\xa5\x59\x49\x3a\xb5\xff\xea\xbf\xf8\x92\xda\x5c\x54\xbc\xfb\x31\xb4\x70\xf2\x9a\x51\x8c\x36\x6b\xf3\x5c\x3c\xf5\x9e\x7b\x23\x1f\x9c\x08\x30\x71\xce\xaa\x5f\xbf\xc0\xc4\xaa\x95\xb4\x10\x5f\x04\x56\x6b\xf1\xcc\xa7\x7f\x37\x5a\x48\x9b\x03\xf9\x29\x9e\xe5\xc4\xfb\xc1\xe0\x1f\x37\xde\x4f\x87\xc3\x4b\x98\x5d\x46\x0b\xc6\xc2\x45\xd8\x8c\x7c\x8f\x92\x0f\x2b\xa6\x92\xe1\x2c\x6a\xb5\xe5\xab\x4b\x98\x9c\x18\x8b\x0e\xc9\xfb\x6e\x03\xd3\xde\xc6\x1b\x0d\xc9\xf2\xee\x6e\xc0\xa0\x2b\xf3\xfe\xfb\x79\x04\x80\xd7\x54\x35\x57\x26\xa3\x8d\x90\x05\x02\x98\x0b\x99\x95\x4a\x07\xd2\x7b\xb8\x4e\xf3\xd8\x95\x61\x37\xb7\x65\x5b\x6f\xe4\xde\xc0\x1d\xd8\xbb\x6c\x24\x02\xc0\x87\xac\x2e\xc0\xe8\x2c\x25\xc9\x53\x5c\x88\xbc\xb7\x59\x45\x9f\x77\x98\xe9\x3c\x87\xe9\xff\x83\x6c\x4e\x43\x0f\x94\x8d\x98\x00\xad\x6c\x4a\xf0\x11\xec\x1f\xa4\xf0\x66\x04\xf9\xce\xf5\x6b\x9a\x9c\xb8\x4f\xbd\x67\x81\x90\x29\x4c\xf8\x9f\xd0\x77\x04\xf2\xd0\x14\x04\x48\x50\x43\x1a\x59\x8c\x17\xf7\x2e\x8a\xff\x63\xd6\x50\x72\xfc\xcf\x4a\xc9\xfc\xd3\xec\x94\xdc\x83\x01\xfa\xaf\x1f\x15\xfe\x17\xdf\xd4\x04\x48\xdd\x7c\x26\x40\x54\xb1\x3d\x52\xbe\x15\x8c\x2b\x78\x37\x71\x3f\xcf\x67\x04\x32\x25\xad\x56\x95\x01\xf4\xd7\xa2\x19\xbe\xf0\x01\xfe\x9e\x6b\x8d\xde\x6f\x96\x8b\x24\x04\x96\xe3\x9b\xcb\xf0\x37\x97\xcc\xfb\xe8\x9f\x00\x00\x00\xff\xff\x47\x39\xe3\x7d\xf2\x07\x00\x00")
+var _gou_templateRecordTxt = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xb5\x55\xdf\x6b\xdb\x30\x10\x7e\xef\x5f\x71\x88\x0d\x92\x42\x9d\x34\xeb\x5e\x4a\x12\xe8\x2f\xda\x30\xc6\xca\x52\xf6\x52\x46\x50\x2c\xd9\x56\x6b\x4b\x9e\xa4\x74\x4d\x3d\xff\xef\xd3\xc9\x4e\x6a\x67\x5e\xcb\x06\x7d\x09\xd6\xdd\xe9\xbe\xfb\xee\xbe\x53\x8a\x62\xb0\xbf\x07\x67\x2a\x5f\x6b\x11\x27\x16\x7a\x61\x1f\x46\xc3\xe1\xc7\x83\xd1\xf0\xf0\x08\x4c\x22\xe4\xe5\xc5\x8d\x59\xc1\xb5\x56\x77\x3c\xb4\xc1\x1e\xec\x0f\xca\x72\xaf\x28\x18\x8f\x84\xe4\x40\x34\x0f\x95\x66\xc4\xd9\xc6\xcc\x82\x60\x13\xa2\x8b\x22\x98\x0b\x56\x96\x04\x18\xb5\xf4\xa0\x8a\x38\x40\xd7\xd6\x33\x75\x19\x40\x44\x10\xcc\xcc\x09\xcb\x84\x04\x77\x1f\x60\x2c\x64\xbe\xb2\x60\xd7\x39\x9f\x90\x30\xe1\xe1\xfd\x52\x3d\x12\x90\x34\x73\xe7\x1a\x08\x1e\x68\xba\xe2\x3e\xd5\x57\x1e\x5e\x71\xca\x82\xb9\xa5\x59\x5e\x96\x8b\x86\x69\x76\x8e\xf0\x03\x0f\xc3\x25\xc3\xf4\x63\x0a\x89\xe6\x91\xbf\x79\xe3\xbe\x28\x3b\xbb\x9c\x95\xe5\xa0\x28\x8c\xd5\x17\x32\x54\x8c\x43\x70\x4d\x6d\xe2\x6d\x1b\x06\x61\x4a\x8d\x99\x10\xe1\x80\x91\x80\x78\xf6\x54\x55\x3d\x1b\xa6\x9b\xaf\xf1\x80\x22\xee\x3b\x0c\x38\x9e\x60\x49\xc1\x25\xb7\xa7\x8a\xad\xbf\x61\xe9\x40\xd0\x41\x80\x10\xf0\x8d\xc4\x36\xf8\xd8\xba\x07\x26\xa7\x72\x03\xeb\x23\xa7\x75\x2e\xcc\x8c\xce\x8a\x54\x6a\x5e\xba\x10\x7c\xe6\xc6\xd0\x98\x07\x54\x2a\xb9\xce\xd4\xca\xb4\x6f\x57\x2d\x71\x89\x33\x2a\xd2\xce\x22\xd1\xb1\x53\x24\x9a\x3c\xe4\x6d\x7d\xb1\x2c\xbf\xb7\xb3\xe5\xab\xe5\x3d\x5f\xd7\xf9\xe6\x89\xd2\xf6\xda\x5b\x1a\x49\xaa\x90\xed\xd9\xb5\x2c\x96\x5f\x3e\x75\x50\x31\xce\x41\xc0\x0a\x9b\x56\xd3\xde\x30\x42\x3b\xb5\x2b\xed\xfa\x71\x5c\x4d\x7c\xa7\x72\x4b\x75\xcc\x2d\xd6\x5e\x4d\x65\x0b\xf9\x7a\xfb\x30\x37\x44\xca\xdd\x67\x5d\xd0\x95\x67\x81\x51\x2f\xa4\xde\x74\x63\x2b\xbc\x16\x02\x6a\xb5\x5e\x0c\xff\xdd\xa5\x64\x4c\x9d\xaa\x90\xa6\x56\x38\x59\xec\x7a\x1b\x58\xd8\x40\x6c\xc0\x15\x35\x8d\x06\x50\x6b\x69\x98\x90\x8a\xde\x5f\x55\x1f\x9c\x53\x1b\x89\x94\x57\x87\xe6\xe2\x0c\xfe\xac\x28\x40\x71\xaf\xa2\x48\x3c\xd6\x4a\x7f\xc1\xef\xf5\x0f\xd0\x2b\x0a\xab\x3e\x9d\x42\xcf\xaa\x99\xb4\x10\x9c\xf8\xaa\xe6\xe2\x89\xf7\x7f\xe5\x5a\x48\x1b\x01\x79\x1f\x0c\x23\x57\x67\xa3\xc5\xf7\xcb\xb2\xec\x37\x9b\x37\x60\x76\xea\x9e\x16\xe6\xd7\x6f\xd9\xda\x36\xa4\xbc\x15\x12\x75\xf1\xae\xaa\x4c\x3d\xf0\xd9\x39\xf4\x3a\xda\xa2\xbd\x73\x51\x4d\xa0\x5f\x4f\x7f\xa9\xdd\x2b\x71\x7b\xdb\xa8\xa0\x0a\x73\xd2\x3e\x76\x7e\x7c\x1c\x54\x7e\x61\x42\x9a\x0b\x19\x23\x80\x39\x91\xa1\x13\xb6\x2f\x7a\x03\x57\x71\x6e\xef\x82\x9f\xcd\x4d\xb2\xca\x96\x72\xb3\x36\x15\xd8\x9b\x4c\xc4\x65\xc7\xe7\x33\x8b\xc1\xe8\x70\x42\x06\x8f\x41\x2c\xa2\x5a\x66\x29\x7d\x5a\xa3\xa7\xd2\x1c\xba\xff\x05\xd9\x74\x43\x37\x98\xb5\x2a\x01\x27\xda\x09\xc1\xa7\xb7\x7e\x06\xfd\xaa\x79\xfa\x45\x51\x8f\xa9\xd7\xb1\xb4\xb5\x66\xdd\xd2\xf6\xa1\xc7\x7f\x40\x9d\x11\xc8\x5d\x1e\x3b\xab\x67\x43\x72\x19\xb7\x07\xf7\x26\x8c\x5f\xe9\x35\x24\x1c\xff\x29\x27\x64\x74\x38\xec\xa2\xbb\xbb\xfe\xff\x4b\xfc\x27\x5f\x66\xce\x9c\xe5\x47\xee\x57\xc5\x0f\x3b\xcc\x1f\x04\xe3\x0a\xde\x8c\xdc\x87\x91\x23\x17\x2a\x69\xb5\x4a\x0d\xa0\xbe\xc6\x79\xf3\x7f\xc5\xc3\x2f\xb8\xd6\xa8\xfd\xdc\xb1\xf7\x86\x69\x7b\x73\x19\x9e\xdd\xd1\x9d\x7e\x03\x5f\xd7\x9d\x63\x68\x08\x00\x00")
 
 func gou_templateRecordTxtBytes() ([]byte, error) {
 	return bindataRead(
@@ -1393,7 +1500,7 @@ func gou_templateRecordTxt() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "gou_template/record.txt", size: 2034, mode: os.FileMode(420), modTime: time.Unix(1491401929, 0)}
+	info := bindataFileInfo{name: "gou_template/record.txt", size: 2152, mode: os.FileMode(420), modTime: time.Unix(1792194749, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -1418,6 +1525,26 @@ func gou_templateRemove_file_formTxt() (*asset, error) {
 	return a, nil
 }
 
+var _gou_templateRetentionTxt = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xad\x54\x4d\x8b\xdb\x30\x10\xbd\xe7\x57\x0c\x26\x87\xdd\x85\xda\x6d\x0f\x3d\x2c\xb6\xa1\xec\x96\xd2\x43\x29\x24\xbd\xf4\x14\x14\x6b\x12\x0b\x64\xc9\x48\x72\xc0\x15\xfe\xef\x1d\xc9\xf1\xda\xf9\x80\x5e\xf6\x62\x69\xde\x8c\xe6\xcd\x68\x9e\xec\x7d\xf6\xb4\x82\x17\xdd\xf6\x46\x1c\x6b\x07\x0f\xd5\x23\x7c\xfe\xf8\xe9\x0b\x6c\x6b\xa1\x7a\x06\x7f\xd8\xb1\xef\xd2\x15\x3c\x65\xc3\xb0\xf2\x9e\xe3\x41\x28\x84\xc4\xa0\x43\xe5\x84\x56\x49\x84\xd7\x46\x6b\xf7\x5c\xa4\x64\xe4\x6d\xe9\x7d\xfa\x13\xad\x65\x47\x4c\x39\xda\x6a\xf7\x16\x3c\x0c\x79\xd6\x96\xab\xbc\x93\xe5\x0a\x20\x97\xa2\xb4\xec\x84\xe4\xaf\xb4\xe1\xcf\xe0\x3d\x88\x03\xa4\x5b\xc2\x36\x11\x82\x61\xa0\x5c\xb3\x3d\x0c\xb0\xcc\xcd\x7a\x1b\x02\x00\xa5\xc5\x31\x74\x72\x75\x4a\x8a\x46\x38\xe4\xa3\x5f\x85\x4c\x79\x46\x7c\x4b\x5a\x2b\xfe\x62\x20\x8d\x04\x5b\x32\xee\xc4\x18\x6c\xf4\x09\xaf\x6b\x8b\xd8\xb2\xb8\x08\xbc\x5f\x75\xbd\xa2\x4b\x63\xea\x88\x33\x2f\x41\x9b\x80\x9c\x59\x27\xf3\x1d\x38\xf3\x2c\x8c\xe3\xcc\xb3\x99\x46\x65\x21\xcc\xf2\xa0\x4d\x03\x0d\xba\x5a\xf3\x22\x69\xb5\x75\x09\xb0\x2a\xb8\x8b\x84\x72\x7f\xe5\x8d\x50\x2f\xdf\x7f\x0c\x43\x36\xeb\x21\x36\x21\x54\xdb\x39\x70\x7d\x8b\x45\x52\x0b\xce\x51\x25\xa0\x58\x43\x56\xd5\xf0\x04\x4e\x4c\x76\xb4\x37\x1d\xc1\xd9\x7f\x0e\x58\x31\x1f\x08\x9d\x0b\xea\xe0\xde\x29\xdb\xed\xa9\xbf\x65\xe8\xd4\x3a\xd1\x2c\x25\x98\x40\x25\x99\xb5\x45\xb2\x77\x23\x7d\x9e\x85\x36\x69\x75\x6c\x2f\x71\xf2\x8e\x46\xfc\x7e\xa8\xb4\xa2\x8a\x2c\xf2\xb1\x3b\x67\xc2\x12\x36\xf5\x52\xeb\x4e\x38\x19\x35\x44\xf0\x95\x6b\x54\xb8\x3d\x3b\xef\x1d\xd6\x92\xef\x2e\xa3\x6e\x52\x44\x91\xed\x6e\x52\xd1\x1a\xeb\xa1\x09\x46\xcd\xc0\xda\xd0\x4b\xbc\x1c\xe4\x45\xd1\xbc\xcc\x19\xd4\x06\x0f\xe1\x96\xe2\xcb\x4d\x7f\x93\xc9\xf8\x38\x4a\xef\xad\x33\xdf\x54\xa5\x39\xc2\xc3\x41\x48\x7c\xc5\xb8\x5f\x9b\xf4\x95\xb9\x00\x3c\xd2\x25\x52\x69\x77\x7d\xa1\x2e\x56\x52\x4d\x7c\xa6\x0b\x2c\xe9\x66\x51\xf7\xb5\xef\x97\xe4\x77\xf1\xb7\x87\x35\xf9\x16\xbd\x8e\x12\xa6\xe1\xc5\x11\x45\x01\x9f\x55\x7f\xf5\x0b\x52\xfa\xe6\x07\x34\x1f\xf7\x9e\x36\xb4\xfe\x03\xe5\xcf\x33\xe4\x07\x05\x00\x00")
+
+func gou_templateRetentionTxtBytes() ([]byte, error) {
+	return bindataRead(
+		_gou_templateRetentionTxt,
+		"gou_template/retention.txt",
+	)
+}
+
+func gou_templateRetentionTxt() (*asset, error) {
+	bytes, err := gou_templateRetentionTxtBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "gou_template/retention.txt", size: 1287, mode: os.FileMode(420), modTime: time.Unix(1792197861, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _gou_templateRss1Txt = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x74\x54\x4d\x4f\xe3\x3a\x14\xdd\xfb\x57\xdc\x97\x97\x05\xa0\xd7\xb8\x2d\xef\x2d\x88\x92\x20\xd4\xc2\xd3\x48\x2c\x46\x94\xd1\x20\x21\x16\x21\xbe\x69\x32\xa4\x4e\xc7\x76\x68\x2b\xcb\xff\x7d\x64\x3b\x81\x7e\x88\x5d\x7c\xef\x39\xe7\x5e\x1f\x1f\x45\x6b\x86\x65\xcd\x11\x02\x21\xe5\x24\x30\x26\xb9\xde\xae\x1a\x78\x47\x21\xeb\x96\xa7\xc1\x24\x1a\x07\x80\xbc\x68\x59\xcd\x97\x69\xa0\x75\x74\x6b\x0f\x68\x4c\x70\x9d\x11\xad\xa1\x2e\x21\x7a\x5a\xdc\x83\x31\x04\xc0\x91\x47\x52\xed\x1a\x94\x15\xa2\x82\x4a\x60\xe9\x58\x4f\x8b\x7b\x63\x02\x50\xbb\x35\xa6\x81\xc2\xad\xa2\x5b\xd9\xf4\x12\xc8\x99\xa5\x27\x82\x95\xf1\xc3\xfc\x8e\x00\x6c\x57\x0d\x97\x69\x50\x29\xb5\x8e\x29\x5d\x77\xa2\x89\x5a\xb1\xa4\x42\x4a\x3a\x89\xc6\x34\x18\x20\xb1\x60\xe5\x07\x6c\xb3\xd9\x44\x9b\x4b\x07\x9c\x5c\x5d\x5d\xd1\xf1\x94\x4e\xa7\x23\xc1\xca\x91\xdc\x71\x95\x6f\x47\x5c\xfe\xfd\xc9\x64\xc5\xa9\x3e\x2b\x28\x36\xb8\x42\xae\xec\x9c\xc9\xde\x9c\xa2\xe5\x0a\xb9\xfa\x7a\xa5\x55\xcb\xba\x06\x25\xed\x81\x03\x35\x6e\xf2\xde\xb6\xfb\x9c\x2f\x8d\x09\x32\x92\x14\x55\xce\x39\x36\x60\xaf\x9b\xbf\xb6\x9d\x72\xfd\x1f\x0f\xdf\x7c\x5b\xd5\xaa\xc1\x4c\xeb\x4a\xad\x1a\x88\x1e\xed\xc9\x98\x84\xfa\x32\x49\x9a\x9a\xbf\x65\x56\xaf\xe6\x6f\xc6\xd0\x84\xba\x02\x49\x18\xca\x42\xd4\x6b\x55\xb7\xfc\x83\x3c\xff\xac\x59\x89\x7d\x08\x49\x6a\x85\x2b\x99\x39\xd3\x17\xf8\xdb\x3d\x84\xc8\xf9\x12\x21\x7c\x8f\xd3\xe8\x0e\x91\xc9\xfe\x51\x2d\xa4\xa9\xdd\xbe\x02\x65\xdb\x89\x02\xed\xca\xe1\x7b\xbf\x44\x40\x0f\x9e\x91\x0e\x92\x09\xf5\x33\x48\x42\xfb\x3b\x67\x84\x68\x1d\x8a\xb6\x55\x71\x1a\x19\xb3\x37\xf3\xed\x9f\xb0\x1c\xc6\xfa\xa9\x96\x7b\xe8\x91\xbb\x54\x58\x0e\x53\x33\x8b\x3a\x70\x2b\x2c\x4f\xfc\x02\x18\x1c\x3b\x64\x0f\xbe\x01\x24\xac\x88\x59\xae\x3e\x44\xce\xdc\x7e\xd1\xcf\xcb\xc2\x56\x2d\x63\x9e\x2b\x3c\x77\x06\xf6\x48\x02\xe0\x93\x1f\x96\xd1\x4c\x60\xae\x5a\xe1\x76\xf6\x62\x85\xaf\x64\x5a\xef\xb7\x1d\x7b\x68\x79\x81\xde\x30\xf7\xdd\xdb\x20\xbb\xd7\x38\x0d\xcb\x68\xd1\xbd\xfe\xc2\x42\xc1\x9e\xac\xf4\xa5\x4c\x6b\x94\x45\xbe\xf6\xe0\x5e\x77\xe8\x9d\xea\xfa\x25\x0f\x82\xd0\x2b\x1e\xe4\xe5\x18\x73\x14\x96\x2f\x64\x67\x3e\xec\x83\x64\x9f\xfd\xd8\xfd\x2e\x90\x65\xc9\x5f\xcf\xb3\xf9\xcd\xe3\xcd\xb3\x77\x62\x00\xbf\xbc\x64\x09\x3d\xc6\x1e\x8d\xf0\xd1\x39\x8d\xd5\xc3\xfc\xce\x16\x91\x33\x63\x6c\x96\xe8\x05\x81\x59\xbb\xde\x89\x7a\x59\x29\x38\x2b\xce\x61\x3a\x1e\xff\x37\x9a\x8e\x27\xff\x82\xac\x6a\xfe\xff\xed\xa3\xec\xe0\xbb\x68\xad\x3d\x11\x81\x0b\x6a\x0c\xf9\x13\x00\x00\xff\xff\x89\x81\x90\x8b\xf4\x04\x00\x00")
 
 func gou_templateRss1TxtBytes() ([]byte, error) {
@@ -1438,7 +1565,7 @@ func gou_templateRss1Txt() (*asset, error) {
 	return a, nil
 }
 
-var _gou_templateSearch_formTxt = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4c\xce\x4d\x4b\xf3\x40\x10\xc0\xf1\xfb\x7e\x8a\x61\x4e\xcf\x53\x30\x1b\x4b\xbd\x48\x36\x20\x45\x8a\x07\x41\xc1\xbb\xac\xc9\x34\x59\x71\x5f\xdc\x17\x31\x2e\xf3\xdd\x25\xb6\x87\xde\x86\x19\xfe\x3f\xa6\x56\xb9\x11\xb0\xf7\x61\x89\x66\x9a\x33\xfc\x1b\xfe\xc3\xb6\x6d\x6f\xae\xb6\xed\xf5\x0e\xd2\x6c\xdc\xe1\xfe\x25\x15\x78\x8a\xfe\x9d\x86\xdc\x08\xd8\x48\x66\x51\xeb\x48\x47\xe3\x08\x30\x91\x8e\xc3\xfc\x7a\xf4\xd1\x22\xb3\xe8\xd6\x01\x2c\xe5\xd9\x8f\x0a\x27\xca\x08\x7a\xc8\xc6\x3b\x85\xb5\x36\x77\xa3\x35\x6e\x7f\x78\x60\x96\xa7\x0e\xfb\x2e\xf4\xa2\x33\x2e\x94\x0c\x79\x09\xa4\x30\x95\x37\x6b\x32\xc2\x97\xfe\x28\xf4\x57\x3d\x52\x4a\x7a\xa2\xe6\x94\x30\x23\xc8\x5e\x5c\xec\x23\x4d\xf4\x1d\x98\x6f\xcf\x8e\xd3\x96\x14\x7e\x16\x8a\x0b\x42\x32\x3f\xa4\x70\xd7\x5e\x82\xcf\xeb\xe9\xec\x74\x32\xf4\x9d\x5c\xbf\x5e\x4d\x72\x23\xb3\xf8\x0d\x00\x00\xff\xff\x97\xe4\xd5\x6b\x15\x01\x00\x00")
+var _gou_templateSearch_formTxt = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x65\x4f\x4b\x4b\xc4\x30\x10\xbe\xf7\x57\x0c\x39\xe9\x82\x4d\x5d\xd6\x8b\xa4\x01\x11\xf1\x24\x28\x78\x2f\x31\x99\x6d\xb3\xd8\x24\xe6\xa1\xd4\x90\xff\x6e\xba\xee\x61\xc5\xdb\x37\xf3\xbd\x66\x72\xa6\x9b\x06\xee\xad\x5b\xbc\x1e\xa7\x08\x17\xf2\x12\xb6\x5d\x77\x73\xb5\xed\xae\x77\x10\x26\x6d\x1e\x1f\x5e\x43\x82\x67\x6f\x0f\x28\x63\xdb\xc0\x86\x96\xd2\xe4\xac\x70\xaf\x0d\x02\x09\x28\xbc\x9c\x86\xbd\xf5\x33\xa9\x04\x5b\x01\xcc\x18\x27\xab\x7a\x32\x62\x24\x20\x64\xd4\xd6\xf4\x24\xe7\xf6\xee\x08\x4b\x21\x9c\x39\xde\x30\x6d\x5c\x8a\x10\x17\x87\x3d\x09\xe9\x6d\xd6\x55\xfd\x29\xde\x13\x1e\xc5\x4f\x18\x82\x18\xb1\xfd\x6d\xa8\x26\xa0\xbc\xf9\xb7\x1f\xbe\xac\x57\xa1\x94\xdb\x53\x9a\x11\x73\xb5\x7f\x24\xf4\x0b\x81\xa0\xbf\xeb\xb0\xeb\xce\x63\x5f\x56\xea\x94\xc6\xa8\xe3\x8c\xae\x27\x57\xec\xf8\x59\xb8\xc2\x20\x87\xbf\x0d\xab\xb8\xf6\xa3\x51\xf5\xcf\x1f\x3a\xd8\x8f\x52\x38\x01\x00\x00")
 
 func gou_templateSearch_formTxtBytes() ([]byte, error) {
 	return bindataRead(
@@ -1453,12 +1580,32 @@ func gou_templateSearch_formTxt() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "gou_template/search_form.txt", size: 277, mode: os.FileMode(420), modTime: time.Unix(1448763309, 0)}
+	info := bindataFileInfo{name: "gou_template/search_form.txt", size: 312, mode: os.FileMode(420), modTime: time.Unix(1792195046, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _gou_templateSearch_resultTxt = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xad\x52\x5d\x4f\xc2\x30\x14\x7d\xe7\x57\xdc\x2c\xc4\x28\x89\xdd\x20\xfa\xa2\xdb\x7c\x20\x04\x7c\xd0\xa0\xf0\x4e\xea\x5a\xb6\x9a\xd1\xd5\xb6\x0b\x92\xa5\xff\xdd\xb6\x6b\x00\x8d\xbe\xf9\xb2\xdc\x73\x3f\xce\x3d\xe7\x76\x5d\x17\x8f\x06\x30\x6d\xc4\x41\xb2\xb2\xd2\x70\x59\x5c\xc1\x24\x49\x6e\xaf\x27\xc9\xf8\x06\x54\xc5\xf8\x7c\xb6\x56\x2d\x2c\x65\xf3\x4e\x0b\x8d\x06\x30\x8a\x8d\x19\x74\x1d\xa1\x5b\xc6\x29\x44\x8a\x62\x59\x54\x1b\x49\x55\x5b\xeb\xc8\x97\x86\xb2\x69\xf4\x5d\x86\x2c\x48\x45\xde\x75\x42\x32\xae\xb7\x80\x9e\xa8\x52\xb8\xa4\x28\x8c\x54\x4c\x2b\x40\x0b\xfb\x35\x26\x8d\x45\x6e\x27\x81\xd9\xb6\x57\x4f\xa5\xc0\x8d\x93\x1a\x18\xc9\x7e\x2c\xf1\x9d\x12\xf3\x92\xc2\x50\xda\x3d\x67\x03\x00\x29\xd1\x79\x8a\xa1\x92\x74\x9b\x45\x41\x0b\x5a\x5b\x88\xc9\x74\xfe\x68\x4c\xdc\x75\x4a\xcb\x19\x2f\x1a\xe2\xc6\xd1\x9a\xe9\x9a\xfa\xb4\x05\x2b\x46\x8c\x89\x72\x1f\x87\x42\x1a\xe3\xdc\xd1\x2a\x81\x39\x14\x35\x56\xca\xca\xd1\x78\x27\x22\x20\x58\xe3\x6b\x1f\xf7\x9b\xd0\xca\xc5\x3d\x41\xdd\x14\xb8\xd6\x6c\xe7\x77\x84\x7c\x1a\x3b\x92\x3c\x8d\xad\x44\xaf\x94\xf4\x9b\x56\x9c\x09\x41\xb5\x6b\xb0\x29\x67\x8e\x72\xe2\xed\xc7\xa4\xfe\x86\x8f\x47\x2a\x35\xa0\xa5\xbd\x25\x24\xc1\xf5\xc9\x31\x9a\x63\x4d\xf7\xf8\xd0\xbb\xed\x2f\xf7\xf0\xd1\x52\x79\xc8\xce\xad\xa3\x17\x97\x32\xe6\xc2\x4a\xbb\x17\x96\xca\x55\xdb\xb7\x40\x3b\xee\x5d\x1c\x9f\x8c\xd3\xfd\xc6\x35\x85\x7b\x9c\x24\x85\x37\x5b\x60\xf5\x4c\x3f\xf5\x7f\x8a\xc1\x84\xfc\x21\xa6\xa9\xc9\xef\x62\xc2\x5f\x64\x91\x05\x5f\xd6\x55\x01\xe9\xda\x02\x00\x00")
+
+func gou_templateSearch_resultTxtBytes() ([]byte, error) {
+	return bindataRead(
+		_gou_templateSearch_resultTxt,
+		"gou_template/search_result.txt",
+	)
+}
+
+func gou_templateSearch_resultTxt() (*asset, error) {
+	bytes, err := gou_templateSearch_resultTxtBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "gou_template/search_result.txt", size: 730, mode: os.FileMode(420), modTime: time.Unix(1792195046, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _gou_templateStatusTxt = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x7c\x8e\xc1\x4a\x03\x31\x10\x86\xef\x79\x8a\x61\xd9\x83\x16\xdd\xd4\x45\x2f\x25\xbb\x17\x11\x4f\x8a\xa0\x2f\x10\x9b\xb1\x1b\x9b\x26\x92\x64\x8b\x65\x98\x77\x97\x6c\xb7\x14\x41\x3c\x85\xfc\xfc\xf3\x7d\x3f\x91\x5c\x08\xb8\x0f\x5f\x87\x68\x37\x43\x86\x8b\xf5\x25\xb4\xcb\xe5\xdd\x75\xbb\xbc\xb9\x85\x34\x58\xff\xf8\xf0\x96\x46\x78\x89\xe1\x13\xd7\xb9\x11\xb0\x90\xcc\x82\xc8\xe0\x87\xf5\x08\x55\xca\x3a\x8f\xa9\x9a\xb2\x3a\x86\x90\x57\x5d\xc3\x2c\x54\xd6\xef\x0e\x21\x8d\xbb\x9d\x8e\x87\xae\x22\x6a\x9e\x30\x25\xbd\xc1\xe6\x78\xc1\x5c\xc1\xda\xe9\x94\xba\x2a\x05\x67\x4d\xd5\x0b\x22\x88\xda\x6f\x10\xea\xed\x55\xbd\x5f\x75\xcd\xeb\xd4\x04\x66\x01\xa0\x72\xec\x55\x36\x3d\x91\xf5\x06\xbf\x61\x72\x9d\x98\x50\x6f\x99\x95\xcc\x66\xae\xd4\xfb\xd3\x57\xe6\x38\x81\xd1\x9b\xc2\x51\x72\xda\xf5\x87\xeb\x39\x18\xfc\xe5\x1b\xda\x7f\x5d\x43\xdb\x97\xd6\xe8\xca\x73\xa6\xf9\x60\x70\xd5\xd5\xfb\x23\x04\x40\x39\x5b\xf6\x94\xb8\x5c\x39\x3b\xd7\xe7\x3d\x00\x4a\x16\xc4\x39\x21\x42\x6f\x98\xc5\x4f\x00\x00\x00\xff\xff\x38\xc5\x00\x17\x99\x01\x00\x00")
+var _gou_templateStatusTxt = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x7d\x90\x51\x4b\xc3\x30\x14\x85\xdf\xfb\x2b\x2e\xa1\x0f\x3a\xb4\x9d\x43\x5f\x46\x5b\x90\x21\xc3\x07\x45\xd0\xf7\x11\x9b\xbb\x35\xae\x4b\x6b\x92\x0e\x47\xc8\x7f\xf7\xa6\x75\xce\x89\xee\x29\xbd\x27\xe7\x3b\xb9\x3d\xce\xa5\xa3\x08\x66\x4d\xbb\xd3\x72\x55\x59\x38\x2b\xcf\x61\x32\x1e\xdf\x5c\x4e\xc6\x57\xd7\x60\x2a\xa9\xe6\x77\x2f\xa6\x83\x27\xdd\xbc\x61\x69\x93\x08\x46\xa9\xf7\x91\x73\x02\x97\x52\x21\x30\x63\xb9\xed\x0c\xeb\xb5\x58\x37\x8d\x9d\xe6\x09\x0d\x99\xe5\xaf\x35\x82\xe9\x36\x1b\xae\x77\x39\x73\x2e\x79\x40\x63\xf8\x0a\x93\x81\xf0\x9e\x41\x59\x73\x63\x72\x66\x9a\x5a\x0a\x56\x50\x00\x68\xae\x56\x08\xf1\xfa\x22\xde\x52\xce\x73\xef\x04\x8a\x03\xc8\xac\x2e\x32\x2b\x0a\xe7\xa4\x12\xf8\x01\xfd\x5b\xfb\x4c\x22\xbc\xcf\x52\xba\x1e\x2c\xf1\x76\x3f\xa6\x84\x85\x60\x54\x22\xe4\xd0\x1c\xf6\x2a\xa2\xac\x2d\x32\x0e\x95\xc6\x65\xbf\xdb\xad\xd8\x48\x35\x9b\xdf\x7b\x9f\x76\xad\xe0\x16\x0d\x2b\x7e\xac\x3c\x68\x8b\xf7\x0e\x3b\x0c\xc9\xbc\x80\x7f\x68\xd5\x88\x5f\x6c\x50\x16\x15\xf2\xda\x56\xa7\x51\x8d\x16\x95\x95\x8d\x3a\xc2\xbf\xd5\xd3\xf0\xd2\x94\xeb\x23\x2e\x08\x03\x92\xa5\xed\x1f\xdd\x3e\xd2\x5a\x47\xfd\x56\x93\x93\xdd\xd2\x75\x70\x75\x75\x38\x0e\x69\xe1\xef\xa6\x79\xbc\x1d\x42\xc8\x50\xcb\xd0\x7f\x90\x03\x45\xd3\x60\xff\xea\x9f\x0c\x69\x88\x38\x28\xce\xd1\x07\x9d\x9f\x12\x30\x9d\xc5\x89\x02\x00\x00")
 
 func gou_templateStatusTxtBytes() ([]byte, error) {
 	return bindataRead(
@@ -1473,7 +1620,7 @@ func gou_templateStatusTxt() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "gou_template/status.txt", size: 409, mode: os.FileMode(420), modTime: time.Unix(1448763309, 0)}
+	info := bindataFileInfo{name: "gou_template/status.txt", size: 649, mode: os.FileMode(420), modTime: time.Unix(1792198231, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -1610,6 +1757,7 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
+	"gou_template/update_queue.txt": gou_templateUpdate_queueTxt,
 	"www/00default.css": www00defaultCss,
 	"www/00initialize.js": www00initializeJs,
 	"www/20jump.js": www20jumpJs,
@@ -1620,6 +1768,7 @@ var _bindata = map[string]func() (*asset, error){
 	"www/20tagedit.js": www20tageditJs,
 	"www/20textarea.js": www20textareaJs,
 	"www/21resanchor.js": www21resanchorJs,
+	"www/30liveupdate.js": www30liveupdateJs,
 	"www/40recform.js": www40recformJs,
 	"www/41postadvanced.js": www41postadvancedJs,
 	"www/arazuki_saku.png": wwwArazuki_sakuPng,
@@ -1654,6 +1803,7 @@ var _bindata = map[string]func() (*asset, error){
 	"file/initnode.txt": fileInitnodeTxt,
 	"file/message-en.txt": fileMessageEnTxt,
 	"file/message-ja.txt": fileMessageJaTxt,
+	"file/moderator.txt": fileModeratorTxt,
 	"file/motd.txt": fileMotdTxt,
 	"file/node_allow.txt": fileNode_allowTxt,
 	"file/node_deny.txt": fileNode_denyTxt,
@@ -1664,18 +1814,22 @@ var _bindata = map[string]func() (*asset, error){
 	"gou_template/delete_record.txt": gou_templateDelete_recordTxt,
 	"gou_template/edit_tag.txt": gou_templateEdit_tagTxt,
 	"gou_template/footer.txt": gou_templateFooterTxt,
+	"gou_template/fsck.txt": gou_templateFsckTxt,
 	"gou_template/header.txt": gou_templateHeaderTxt,
 	"gou_template/index_list.txt": gou_templateIndex_listTxt,
 	"gou_template/jump.txt": gou_templateJumpTxt,
 	"gou_template/list_item.txt": gou_templateList_itemTxt,
 	"gou_template/menubar.txt": gou_templateMenubarTxt,
 	"gou_template/new_element_form.txt": gou_templateNew_element_formTxt,
+	"gou_template/node_health.txt": gou_templateNode_healthTxt,
 	"gou_template/page_navi.txt": gou_templatePage_naviTxt,
 	"gou_template/post_form.txt": gou_templatePost_formTxt,
 	"gou_template/record.txt": gou_templateRecordTxt,
 	"gou_template/remove_file_form.txt": gou_templateRemove_file_formTxt,
+	"gou_template/retention.txt": gou_templateRetentionTxt,
 	"gou_template/rss1.txt": gou_templateRss1Txt,
 	"gou_template/search_form.txt": gou_templateSearch_formTxt,
+	"gou_template/search_result.txt": gou_templateSearch_resultTxt,
 	"gou_template/status.txt": gou_templateStatusTxt,
 	"gou_template/thread_bottom.txt": gou_templateThread_bottomTxt,
 	"gou_template/thread_tags.txt": gou_templateThread_tagsTxt,
@@ -1727,6 +1881,7 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		"initnode.txt": &bintree{fileInitnodeTxt, map[string]*bintree{}},
 		"message-en.txt": &bintree{fileMessageEnTxt, map[string]*bintree{}},
 		"message-ja.txt": &bintree{fileMessageJaTxt, map[string]*bintree{}},
+		"moderator.txt": &bintree{fileModeratorTxt, map[string]*bintree{}},
 		"motd.txt": &bintree{fileMotdTxt, map[string]*bintree{}},
 		"node_allow.txt": &bintree{fileNode_allowTxt, map[string]*bintree{}},
 		"node_deny.txt": &bintree{fileNode_denyTxt, map[string]*bintree{}},
@@ -1739,23 +1894,28 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		"delete_record.txt": &bintree{gou_templateDelete_recordTxt, map[string]*bintree{}},
 		"edit_tag.txt": &bintree{gou_templateEdit_tagTxt, map[string]*bintree{}},
 		"footer.txt": &bintree{gou_templateFooterTxt, map[string]*bintree{}},
+		"fsck.txt": &bintree{gou_templateFsckTxt, map[string]*bintree{}},
 		"header.txt": &bintree{gou_templateHeaderTxt, map[string]*bintree{}},
 		"index_list.txt": &bintree{gou_templateIndex_listTxt, map[string]*bintree{}},
 		"jump.txt": &bintree{gou_templateJumpTxt, map[string]*bintree{}},
 		"list_item.txt": &bintree{gou_templateList_itemTxt, map[string]*bintree{}},
 		"menubar.txt": &bintree{gou_templateMenubarTxt, map[string]*bintree{}},
 		"new_element_form.txt": &bintree{gou_templateNew_element_formTxt, map[string]*bintree{}},
+		"node_health.txt": &bintree{gou_templateNode_healthTxt, map[string]*bintree{}},
 		"page_navi.txt": &bintree{gou_templatePage_naviTxt, map[string]*bintree{}},
 		"post_form.txt": &bintree{gou_templatePost_formTxt, map[string]*bintree{}},
 		"record.txt": &bintree{gou_templateRecordTxt, map[string]*bintree{}},
 		"remove_file_form.txt": &bintree{gou_templateRemove_file_formTxt, map[string]*bintree{}},
+		"retention.txt": &bintree{gou_templateRetentionTxt, map[string]*bintree{}},
 		"rss1.txt": &bintree{gou_templateRss1Txt, map[string]*bintree{}},
 		"search_form.txt": &bintree{gou_templateSearch_formTxt, map[string]*bintree{}},
+		"search_result.txt": &bintree{gou_templateSearch_resultTxt, map[string]*bintree{}},
 		"status.txt": &bintree{gou_templateStatusTxt, map[string]*bintree{}},
 		"thread_bottom.txt": &bintree{gou_templateThread_bottomTxt, map[string]*bintree{}},
 		"thread_tags.txt": &bintree{gou_templateThread_tagsTxt, map[string]*bintree{}},
 		"thread_top.txt": &bintree{gou_templateThread_topTxt, map[string]*bintree{}},
 		"top.txt": &bintree{gou_templateTopTxt, map[string]*bintree{}},
+		"update_queue.txt": &bintree{gou_templateUpdate_queueTxt, map[string]*bintree{}},
 	}},
 	"www": &bintree{nil, map[string]*bintree{
 		"00default.css": &bintree{www00defaultCss, map[string]*bintree{}},
@@ -1768,6 +1928,7 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		"20tagedit.js": &bintree{www20tageditJs, map[string]*bintree{}},
 		"20textarea.js": &bintree{www20textareaJs, map[string]*bintree{}},
 		"21resanchor.js": &bintree{www21resanchorJs, map[string]*bintree{}},
+		"30liveupdate.js": &bintree{www30liveupdateJs, map[string]*bintree{}},
 		"40recform.js": &bintree{www40recformJs, map[string]*bintree{}},
 		"41postadvanced.js": &bintree{www41postadvancedJs, map[string]*bintree{}},
 		"arazuki_saku.png": &bintree{wwwArazuki_sakuPng, map[string]*bintree{}},
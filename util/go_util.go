@@ -39,6 +39,7 @@ import (
 	"io"
 	"io/ioutil"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"regexp"
@@ -97,6 +98,35 @@ func init() {
 	}
 }
 
+//localNets is networks which are not reachable from the internet.
+var localNets []*net.IPNet
+
+func init() {
+	for _, cidr := range []string{
+		"0.0.0.0/8", "10.0.0.0/8", "100.64.0.0/10", "127.0.0.0/8", "169.254.0.0/16",
+		"172.16.0.0/12", "192.168.0.0/16", "fc00::/7", "fe80::/10",
+	} {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			log.Fatal(err)
+		}
+		localNets = append(localNets, n)
+	}
+}
+
+//IsGlobalIP returns true if ip is a global unicast address of IPv4 or IPv6.
+func IsGlobalIP(ip net.IP) bool {
+	if ip == nil || !ip.IsGlobalUnicast() {
+		return false
+	}
+	for _, n := range localNets {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
 //EachIOLine iterates each line to  a ReadCloser ,calls func and close f.
 func EachIOLine(f io.ReadCloser, handler func(line string, num int) error) error {
 	defer Fclose(f)
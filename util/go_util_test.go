@@ -31,6 +31,7 @@ package util
 import (
 	"encoding/hex"
 	"log"
+	"net"
 	"testing"
 )
 
@@ -50,3 +51,23 @@ func TestEmbedURL(t *testing.T) {
 	log.Println(string(h))
 
 }
+
+func TestIsGlobalIP(t *testing.T) {
+	for ip, global := range map[string]bool{
+		"8.8.8.8":        true,
+		"192.168.1.1":    false,
+		"10.1.2.3":       false,
+		"127.0.0.1":      false,
+		"2001:db8::1":    true,
+		"2400:4050::1":   true,
+		"::1":            false,
+		"fe80::1":        false,
+		"fd00::1":        false,
+		"::ffff:8.8.8.8": true,
+		"ff02::1":        false,
+	} {
+		if IsGlobalIP(net.ParseIP(ip)) != global {
+			t.Error(ip, "should be", global)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+/* Live update of records by server-sent events.
+ * Copyright (C) 2016 Shinya Yagyu.
+ */
+
+shingetsu.initialize(function () {
+    var $records = $('dl#records');
+    var file = $('input[name="file"]').first().val();
+    if (!window.EventSource || $records.length == 0 || !file) {
+        return;
+    }
+    // only on the newest page.
+    if (/\/(p[0-9]+|[0-9a-f]{8})$/.test(location.pathname)) {
+        return;
+    }
+    var source = new EventSource(shingetsu.rootPath + 'gateway.cgi/events/' + file);
+    source.addEventListener('record', function (e) {
+        var rec = JSON.parse(e.data);
+        if ($('#r' + rec.id.substr(0, 8)).length > 0) {
+            return;
+        }
+        var $container = $('<dl>').html(rec.html);
+        shingetsu.modifyRecords($container);
+        $records.append($container.children());
+    });
+});